@@ -0,0 +1,241 @@
+package mongoid
+
+import (
+	"mongoid/log"
+	"sort"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Aggregation facilitates building an aggregation pipeline against the collection of a ModelType, one stage at a time.
+// Each method returns a new Aggregation with the additional stage, leaving the original unaltered so it may be reused as a starting point.
+type Aggregation interface {
+	Match(queries ...Query) Aggregation
+	MatchCriteria(criteria Criteria) Aggregation
+	Group(id interface{}, accumulators Query) Aggregation
+	Project(projection Query) Aggregation
+	Asc(fields ...string) Aggregation
+	Desc(fields ...string) Aggregation
+	Skip(skip int64) Aggregation
+	Limit(limit int64) Aggregation
+	Unwind(path string, preserveNullAndEmptyArrays bool) Aggregation
+	Lookup(from, localField, foreignField, as string) Aggregation
+	Facet(facets map[string]Aggregation) Aggregation
+	Bucket(groupBy interface{}, boundaries []interface{}, defaultBucket interface{}, output Query) Aggregation
+	Stage(stage bson.D) Aggregation
+	Pipeline() bson.A
+	X() *Result
+	All(results interface{}) error
+}
+
+type aggregationStruct struct {
+	sourceModel  *ModelType
+	stages       bson.A
+	queryOptions queryOptions // driver options, carried over from the criteria of MatchCriteria
+}
+
+// Aggregate starts a new aggregation pipeline against the collection of the ModelType
+func (model *ModelType) Aggregate() Aggregation {
+	log.Debugf("%v.Aggregate()", model.GetModelName())
+	return &aggregationStruct{sourceModel: model, stages: bson.A{}}
+}
+
+// Aggregate starts a new aggregation pipeline against the collection of the criteria's ModelType,
+// with leading stages that select the same documents as the criteria (see Aggregation.MatchCriteria)
+func (criteria *criteriaStruct) Aggregate() Aggregation {
+	log.Debug("Criteria.Aggregate")
+	return criteria.getExecutionModel("Criteria.Aggregate").Aggregate().MatchCriteria(criteria)
+}
+
+// returns a copy of the aggregation with the given stages appended
+func (agg *aggregationStruct) withStages(stages ...interface{}) Aggregation {
+	newStages := make(bson.A, 0, len(agg.stages)+len(stages))
+	newStages = append(newStages, agg.stages...)
+	newStages = append(newStages, stages...)
+	return &aggregationStruct{sourceModel: agg.sourceModel, stages: newStages, queryOptions: agg.queryOptions}
+}
+
+// Match adds a $match stage, where every given query must be matched. Queries are normalized the same way as Criteria.Where.
+func (agg *aggregationStruct) Match(queries ...Query) Aggregation {
+	filters := make([]bson.D, 0, len(queries))
+	for _, query := range queries {
+		filters = append(filters, queryToBsonD(query))
+	}
+	return agg.withStages(bson.D{{Key: "$match", Value: mergeBsonDFilters(filters...)}})
+}
+
+// MatchCriteria adds the stages that select the same documents as the given criteria: a $match of its filter,
+// along with $sort, $skip and $limit stages when the criteria uses them.
+// Options of the criteria that apply to aggregation (ie, Collation, Hint, MaxTime, AllowDiskUse) are used when the aggregation is executed.
+func (agg *aggregationStruct) MatchCriteria(criteria Criteria) Aggregation {
+	newAgg := agg.withStages(criteria.(*criteriaStruct).getPipelineBsonA()...).(*aggregationStruct)
+	newAgg.queryOptions = criteria.(*criteriaStruct).getQueryOptions()
+	return newAgg
+}
+
+// the driver options for executing the aggregation
+func (agg *aggregationStruct) getAggregateOptions() *options.AggregateOptions {
+	aggregateOpts := options.Aggregate()
+	agg.queryOptions.applyToAggregate(aggregateOpts)
+	return aggregateOpts
+}
+
+// Group adds a $group stage, grouping by the given id expression (ie, "$field", or nil for everything) and calculating the given accumulators
+// (ie, Q{"total": Q{"$sum": "$amount"}})
+func (agg *aggregationStruct) Group(id interface{}, accumulators Query) Aggregation {
+	groupD := append(bson.D{{Key: "_id", Value: id}}, queryToSortedBsonD(accumulators)...)
+	return agg.withStages(bson.D{{Key: "$group", Value: groupD}})
+}
+
+// Project adds a $project stage, reshaping each document with the given projection (ie, Q{"name": 1, "total": Q{"$add": bson.A{"$a", "$b"}}})
+func (agg *aggregationStruct) Project(projection Query) Aggregation {
+	return agg.withStages(bson.D{{Key: "$project", Value: queryToSortedBsonD(projection)}})
+}
+
+// Asc adds a $sort stage ordering by each of the given fields in ascending order
+func (agg *aggregationStruct) Asc(fields ...string) Aggregation {
+	return agg.sortStage(Ascending, fields...)
+}
+
+// Desc adds a $sort stage ordering by each of the given fields in descending order
+func (agg *aggregationStruct) Desc(fields ...string) Aggregation {
+	return agg.sortStage(Descending, fields...)
+}
+
+func (agg *aggregationStruct) sortStage(direction SortDirection, fields ...string) Aggregation {
+	sortD := bson.D{}
+	for _, field := range fields {
+		sortD = append(sortD, bson.E{Key: agg.sourceModel.GetBsonFieldPath(field), Value: int32(direction)})
+	}
+	return agg.withStages(bson.D{{Key: "$sort", Value: sortD}})
+}
+
+// Skip adds a $skip stage
+func (agg *aggregationStruct) Skip(skip int64) Aggregation {
+	return agg.withStages(bson.D{{Key: "$skip", Value: skip}})
+}
+
+// Limit adds a $limit stage
+func (agg *aggregationStruct) Limit(limit int64) Aggregation {
+	return agg.withStages(bson.D{{Key: "$limit", Value: limit}})
+}
+
+// Unwind adds an $unwind stage, producing one document for each element of the array at the given field path.
+// When preserveNullAndEmptyArrays is true, documents with a missing or empty array are kept rather than dropped.
+func (agg *aggregationStruct) Unwind(path string, preserveNullAndEmptyArrays bool) Aggregation {
+	unwindD := bson.D{
+		{Key: "path", Value: "$" + agg.sourceModel.GetBsonFieldPath(path)},
+		{Key: "preserveNullAndEmptyArrays", Value: preserveNullAndEmptyArrays},
+	}
+	return agg.withStages(bson.D{{Key: "$unwind", Value: unwindD}})
+}
+
+// Lookup adds a $lookup stage, joining the documents of the from collection where foreignField matches the localField, into the as field.
+// The localField may be given by its Go struct field name, while foreignField is a field path of the from collection.
+func (agg *aggregationStruct) Lookup(from, localField, foreignField, as string) Aggregation {
+	lookupD := bson.D{
+		{Key: "from", Value: from},
+		{Key: "localField", Value: agg.sourceModel.GetBsonFieldPath(localField)},
+		{Key: "foreignField", Value: foreignField},
+		{Key: "as", Value: as},
+	}
+	return agg.withStages(bson.D{{Key: "$lookup", Value: lookupD}})
+}
+
+// Facet adds a $facet stage, running each of the given aggregations as a sub-pipeline over the same input documents.
+// The sub-pipelines are typically started from the same ModelType (ie, Model.Aggregate().Group(...)).
+func (agg *aggregationStruct) Facet(facets map[string]Aggregation) Aggregation {
+	facetD := bson.D{}
+	for _, k := range sortedAggregationKeys(facets) {
+		facetD = append(facetD, bson.E{Key: k, Value: facets[k].Pipeline()})
+	}
+	return agg.withStages(bson.D{{Key: "$facet", Value: facetD}})
+}
+
+// Bucket adds a $bucket stage, grouping documents by which of the given boundaries the groupBy expression falls between.
+// Documents outside of the boundaries are grouped into defaultBucket (which is omitted when nil), and output may be nil to only count each bucket.
+func (agg *aggregationStruct) Bucket(groupBy interface{}, boundaries []interface{}, defaultBucket interface{}, output Query) Aggregation {
+	bucketD := bson.D{
+		{Key: "groupBy", Value: groupBy},
+		{Key: "boundaries", Value: boundaries},
+	}
+	if defaultBucket != nil {
+		bucketD = append(bucketD, bson.E{Key: "default", Value: defaultBucket})
+	}
+	if output != nil {
+		bucketD = append(bucketD, bson.E{Key: "output", Value: queryToSortedBsonD(output)})
+	}
+	return agg.withStages(bson.D{{Key: "$bucket", Value: bucketD}})
+}
+
+// Stage adds the given stage as-is, for any stage without a builder method of its own
+func (agg *aggregationStruct) Stage(stage bson.D) Aggregation {
+	return agg.withStages(stage)
+}
+
+// Pipeline returns the stages of the aggregation pipeline, as they will be sent to the database server
+func (agg *aggregationStruct) Pipeline() bson.A {
+	return agg.stages
+}
+
+// X will force eXecution of the aggregation, providing the output documents as model documents within a Result.
+// This is only useful when the output documents are the same shape as the model; otherwise see All.
+// The documents are not flagged as persisted, since the output of an aggregation is not necessarily a record of the collection.
+func (agg *aggregationStruct) X() *Result {
+	model := agg.sourceModel
+	log.Debugf("%v.Aggregation.X()", model.GetModelName())
+	ctx := model.GetClient().Context()
+	collection := model.getReadCollectionHandle(agg.queryOptions)
+	log.Debugf("collection[%s].Aggregate %v", collection.Name(), agg.stages)
+	cur, err := collection.Aggregate(ctx, agg.stages, agg.getAggregateOptions())
+	if err != nil {
+		log.Panic(err) // unknown bad stuff happened within the driver
+	}
+	res := makeResult(ctx, cur, model)
+	res.persisted = false
+	return res
+}
+
+// All executes the aggregation, decoding every output document into results, which must be a pointer to a slice
+// (ie, a slice of user structs with bson tags matching the output shape, or []bson.M)
+func (agg *aggregationStruct) All(results interface{}) error {
+	model := agg.sourceModel
+	log.Debugf("%v.Aggregation.All()", model.GetModelName())
+	ctx := model.GetClient().Context()
+	collection := model.getReadCollectionHandle(agg.queryOptions)
+	log.Debugf("collection[%s].Aggregate %v", collection.Name(), agg.stages)
+	cur, err := collection.Aggregate(ctx, agg.stages, agg.getAggregateOptions())
+	if err != nil {
+		return err
+	}
+	return cur.All(ctx, results)
+}
+
+// returns the keys of the given query in sorted order, so that stages built from it are stable
+func sortedQueryKeys(query Query) []string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// converts the given query into a bson.D as-is (without any operator normalization), in sorted key order
+func queryToSortedBsonD(query Query) bson.D {
+	bsonD := bson.D{}
+	for _, k := range sortedQueryKeys(query) {
+		bsonD = append(bsonD, bson.E{Key: k, Value: query[k]})
+	}
+	return bsonD
+}
+
+func sortedAggregationKeys(facets map[string]Aggregation) []string {
+	keys := make([]string, 0, len(facets))
+	for k := range facets {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
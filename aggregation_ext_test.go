@@ -0,0 +1,44 @@
+package mongoid_test
+
+import (
+	"mongoid"
+
+	gofakeit "github.com/brianvoe/gofakeit/v6"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Aggregation", func() {
+	It("decodes output documents into user structs", func() {
+		OnlineDatabaseOnly(func() {
+			group := gofakeit.UUID()
+			createCriteriaTestModel("a", group, 1)
+			createCriteriaTestModel("b", group, 2)
+			createCriteriaTestModel("b", group, 3)
+
+			type nameTotal struct {
+				Name  string `bson:"_id"`
+				Total int    `bson:"total"`
+			}
+			var totals []nameTotal
+			err := CriteriaTestModels.Where(mongoid.Q{"group": group}).Aggregate().
+				Group("$name", mongoid.Q{"total": mongoid.Q{"$sum": "$number"}}).
+				Asc("_id").
+				All(&totals)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(totals).To(Equal([]nameTotal{{"a", 1}, {"b", 5}}))
+		})
+	})
+
+	It("provides model shaped output as a Result", func() {
+		OnlineDatabaseOnly(func() {
+			group := gofakeit.UUID()
+			createCriteriaTestModel("a", group, 1)
+			match := createCriteriaTestModel("b", group, 2)
+			res := CriteriaTestModels.Aggregate().Match(mongoid.Q{"group": group, "number.$gt": 1}).X()
+			found := res.One().(*CriteriaTestModel)
+			Expect(found.ID).To(Equal(match.ID))
+			Expect(found.IsPersisted()).To(BeFalse())
+		})
+	})
+})
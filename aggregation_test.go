@@ -0,0 +1,108 @@
+package mongoid
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Aggregation", func() {
+	type aggregationTestDoc struct {
+		Base
+		ID        ObjectID `bson:"_id"`
+		TenantID  string
+		LineItems []string
+	}
+	model := &ModelType{modelName: "aggregationTestDoc", rootTypeRef: &aggregationTestDoc{}}
+
+	It("starts with an empty pipeline", func() {
+		Expect(model.Aggregate().Pipeline()).To(Equal(bson.A{}))
+	})
+
+	It("builds each stage in order", func() {
+		agg := model.Aggregate().
+			Match(Q{"tenant_id": "t1", "total.$gt": 5}).
+			Unwind("LineItems", true).
+			Group("$tenant_id", Q{"count": Q{"$sum": 1}, "avg": Q{"$avg": "$total"}}).
+			Project(Q{"count": 1}).
+			Desc("count").
+			Skip(1).
+			Limit(2).
+			Lookup("tenants", "_id", "tenant_id", "tenant").
+			Stage(bson.D{{Key: "$count", Value: "n"}})
+		Expect(agg.Pipeline()).To(Equal(bson.A{
+			bson.D{{Key: "$match", Value: bson.D{{Key: "tenant_id", Value: "t1"}, {Key: "total", Value: bson.M{"$gt": 5}}}}},
+			bson.D{{Key: "$unwind", Value: bson.D{{Key: "path", Value: "$line_items"}, {Key: "preserveNullAndEmptyArrays", Value: true}}}},
+			bson.D{{Key: "$group", Value: bson.D{
+				{Key: "_id", Value: "$tenant_id"},
+				{Key: "avg", Value: Q{"$avg": "$total"}},
+				{Key: "count", Value: Q{"$sum": 1}},
+			}}},
+			bson.D{{Key: "$project", Value: bson.D{{Key: "count", Value: 1}}}},
+			bson.D{{Key: "$sort", Value: bson.D{{Key: "count", Value: int32(-1)}}}},
+			bson.D{{Key: "$skip", Value: int64(1)}},
+			bson.D{{Key: "$limit", Value: int64(2)}},
+			bson.D{{Key: "$lookup", Value: bson.D{
+				{Key: "from", Value: "tenants"},
+				{Key: "localField", Value: "_id"},
+				{Key: "foreignField", Value: "tenant_id"},
+				{Key: "as", Value: "tenant"},
+			}}},
+			bson.D{{Key: "$count", Value: "n"}},
+		}))
+	})
+
+	It("resolves the localField of $lookup stages by struct field name", func() {
+		Expect(model.Aggregate().Lookup("tenants", "TenantID", "_id", "tenant").Pipeline()).To(Equal(bson.A{
+			bson.D{{Key: "$lookup", Value: bson.D{
+				{Key: "from", Value: "tenants"},
+				{Key: "localField", Value: "tenant_id"},
+				{Key: "foreignField", Value: "_id"},
+				{Key: "as", Value: "tenant"},
+			}}},
+		}))
+	})
+
+	It("leaves the original aggregation unaltered when adding stages", func() {
+		base := model.Aggregate().Match(Q{"a": 1})
+		base.Limit(1)
+		Expect(base.Pipeline()).To(HaveLen(1))
+	})
+
+	It("seeds the leading stages from a Criteria", func() {
+		criteria := model.Where(Q{"TenantID": "t1"}).Asc("TenantID").Limit(10)
+		Expect(criteria.Aggregate().Pipeline()).To(Equal(bson.A{
+			bson.D{{Key: "$match", Value: bson.D{{Key: "TenantID", Value: "t1"}}}},
+			bson.D{{Key: "$sort", Value: bson.D{{Key: "tenant_id", Value: int32(1)}}}},
+			bson.D{{Key: "$limit", Value: int64(10)}},
+		}))
+	})
+
+	It("builds $facet stages from sub-aggregations", func() {
+		agg := model.Aggregate().Facet(map[string]Aggregation{
+			"total":   model.Aggregate().Stage(bson.D{{Key: "$count", Value: "n"}}),
+			"bigOnes": model.Aggregate().Match(Q{"total.$gte": 100}),
+		})
+		Expect(agg.Pipeline()).To(Equal(bson.A{
+			bson.D{{Key: "$facet", Value: bson.D{
+				{Key: "bigOnes", Value: bson.A{bson.D{{Key: "$match", Value: bson.D{{Key: "total", Value: bson.M{"$gte": 100}}}}}}},
+				{Key: "total", Value: bson.A{bson.D{{Key: "$count", Value: "n"}}}},
+			}}},
+		}))
+	})
+
+	It("builds $bucket stages, omitting the optional parts when not given", func() {
+		Expect(model.Aggregate().Bucket("$total", []interface{}{0, 10}, nil, nil).Pipeline()).To(Equal(bson.A{
+			bson.D{{Key: "$bucket", Value: bson.D{{Key: "groupBy", Value: "$total"}, {Key: "boundaries", Value: []interface{}{0, 10}}}}},
+		}))
+		Expect(model.Aggregate().Bucket("$total", []interface{}{0, 10}, "other", Q{"n": Q{"$sum": 1}}).Pipeline()).To(Equal(bson.A{
+			bson.D{{Key: "$bucket", Value: bson.D{
+				{Key: "groupBy", Value: "$total"},
+				{Key: "boundaries", Value: []interface{}{0, 10}},
+				{Key: "default", Value: "other"},
+				{Key: "output", Value: bson.D{{Key: "n", Value: Q{"$sum": 1}}}},
+			}}},
+		}))
+	})
+})
@@ -322,6 +322,6 @@ func (c *Client) ApplyURI(uri string) (*Client, error) {
 	return c, nil
 }
 
-func (c *Client) getMongoCollectionHandle(databaseName, collectionName string) *mongo.Collection {
-	return c.MongoDriverClient().Database(databaseName).Collection(collectionName)
+func (c *Client) getMongoCollectionHandle(databaseName, collectionName string, opts ...*options.CollectionOptions) *mongo.Collection {
+	return c.MongoDriverClient().Database(databaseName).Collection(collectionName, opts...)
 }
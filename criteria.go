@@ -2,7 +2,11 @@ package mongoid
 
 import (
 	// "mongoid/log"
+	"time"
+
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
 	// "strconv"
 )
 
@@ -12,10 +16,59 @@ type Query map[string]interface{}
 // Q is shorthand for Query
 type Q = Query
 
+// Regex is a regular expression pattern with options, as implemented by mongo-go-driver.
+// It can be given as the value of a "field.$regex" query key when options (such as "i" for case insensitivity) are needed.
+type Regex = primitive.Regex
+
 // Criteria facilitate the query-building process
 type Criteria interface {
-	// Find(ids ...ObjectID) Criteria
+	Find(ids ...ObjectID) Criteria
 	Where(where ...Query) Criteria
+	Or(queries ...Query) Criteria
+	Nor(queries ...Query) Criteria
+	AnyOf(queries ...Query) Criteria
+	Not(queries ...Query) Criteria
+	OrderBy(field string, direction SortDirection) Criteria
+	Asc(fields ...string) Criteria
+	Desc(fields ...string) Criteria
+	Limit(limit int64) Criteria
+	Skip(skip int64) Criteria
+	Only(fields ...string) Criteria
+	Without(fields ...string) Criteria
+	Count() int64
+	Exists() bool
+	Distinct(field string) []interface{}
+	Pluck(fields ...string) [][]interface{}
+	Sum(field string) interface{}
+	Avg(field string) interface{}
+	Min(field string) interface{}
+	Max(field string) interface{}
+	Aggregate() Aggregation
+	Scoped(name string) Criteria
+	Unscoped() Criteria
+	Paginate(pageSize int64, afterToken string) (*Result, string, error)
+	TextSearch(search string, opts *TextSearchOptions) Criteria
+	SortByTextScore() Criteria
+	Near(field string, point GeoPoint, maxDistance float64) Criteria
+	NearSphere(field string, point GeoPoint, maxDistance float64) Criteria
+	Within(field string, shape GeoShape) Criteria
+	Intersects(field string, geometry GeoGeometry) Criteria
+	Collation(collation Collation) Criteria
+	Hint(index interface{}) Criteria
+	MaxTime(d time.Duration) Criteria
+	BatchSize(n int32) Criteria
+	Comment(comment string) Criteria
+	NoCursorTimeout() Criteria
+	AllowDiskUse() Criteria
+	ReadPreference(mode ReadPreferenceMode, tagSets []map[string]string, maxStaleness time.Duration) Criteria
+	ReadConcern(level string) Criteria
+	First() (IDocumentBase, error)
+	Last() (IDocumentBase, error)
+	Explain(verbosity ExplainVerbosity) (*ExplainResult, error)
+	UpdateAll(update Updater) (BatchResult, error)
+	DeleteAll() (BatchResult, error)
+	ToBsonD() bson.D
+	MarshalJSON() ([]byte, error)
 	X() *Result // see: [criteria_x.go] func (criteria *criteriaStruct) X() *Result
 	getPrevCriteria() Criteria
 	toBsonD() bson.D
@@ -25,6 +78,14 @@ const (
 	_ = iota
 	findCriteria
 	whereCriteria
+	orderCriteria
+	limitCriteria
+	skipCriteria
+	projectionCriteria
+	orCriteria
+	norCriteria
+	anyOfCriteria
+	optionsCriteria
 )
 
 type criteriaStruct struct {
@@ -33,12 +94,90 @@ type criteriaStruct struct {
 	prevCriteria   *criteriaStruct
 	thisQuery      Query
 	thisQueryBsonD bson.D
+	thisOrder      bson.D        // sort fields, for orderCriteria
+	thisNumber     int64         // numeric argument, for limitCriteria and skipCriteria
+	thisProjection bson.D        // projected fields, for projectionCriteria
+	thisQueries    []Query       // query alternatives, for orCriteria, norCriteria and anyOfCriteria
+	thisIDs        []ObjectID    // requested ids, for findCriteria
+	thisOptions    *queryOptions // driver options, for optionsCriteria
+	defaultScoped  bool          // true when the link was added by the default scope of the ModelType
 }
 
 func (criteria *criteriaStruct) getPrevCriteria() Criteria {
 	return criteria.prevCriteria
 }
 
+// returns the ModelType the criteria chain was started from, walking back through the chain as needed
+func (criteria *criteriaStruct) getSourceModel() *ModelType {
+	for link := criteria; link != nil; link = link.prevCriteria {
+		if link.sourceModel != nil {
+			return link.sourceModel
+		}
+	}
+	return nil
+}
+
+// returns every link of the criteria chain, ordered from the root link to this one
+func (criteria *criteriaStruct) getChain() []*criteriaStruct {
+	chain := make([]*criteriaStruct, 0)
+	for link := criteria; link != nil; link = link.prevCriteria {
+		chain = append([]*criteriaStruct{link}, chain...)
+	}
+	return chain
+}
+
+// builds the complete query filter for the criteria chain, where each link must be matched (AND semantics)
+// the exception is Or(), which makes everything before it in the chain just one of the alternatives.
+// The links of the default scope are kept apart from the Or() folding, so that the default scope always applies to every alternative.
+func (criteria *criteriaStruct) getFilterBsonD() bson.D {
+	scopedLinks := make([]*criteriaStruct, 0)
+	otherLinks := make([]*criteriaStruct, 0)
+	for _, link := range criteria.getChain() {
+		if link.defaultScoped {
+			scopedLinks = append(scopedLinks, link)
+		} else {
+			otherLinks = append(otherLinks, link)
+		}
+	}
+	return mergeBsonDFilters(foldFilterBsonD(scopedLinks), foldFilterBsonD(otherLinks))
+}
+
+// builds the filter of the given links, in chain order, folding everything before an Or() link into one of its alternatives
+func foldFilterBsonD(links []*criteriaStruct) bson.D {
+	filters := make([]bson.D, 0)
+	for _, link := range links {
+		if orD := link.toBsonD(); link.criteriaType == orCriteria && len(orD) > 0 {
+			if prevFilter := mergeBsonDFilters(filters...); len(prevFilter) > 0 {
+				orA := append(bson.A{prevFilter}, orD[0].Value.(bson.A)...)
+				orD = bson.D{{Key: "$or", Value: orA}}
+			}
+			filters = []bson.D{orD}
+			continue
+		}
+		filters = append(filters, link.toBsonD())
+	}
+	return mergeBsonDFilters(filters...)
+}
+
+// builds the driver find options (sort, limit, skip, etc) for the criteria chain
+func (criteria *criteriaStruct) getFindOptions() *options.FindOptions {
+	findOpts := options.Find()
+	if sortD := criteria.getSortBsonD(); len(sortD) > 0 {
+		findOpts.SetSort(sortD)
+	}
+	if limit, found := criteria.getNumber(limitCriteria); found {
+		findOpts.SetLimit(limit)
+	}
+	if skip, found := criteria.getNumber(skipCriteria); found {
+		findOpts.SetSkip(skip)
+	}
+	if projectionD := criteria.getProjectionBsonD(); len(projectionD) > 0 {
+		findOpts.SetProjection(projectionD)
+	}
+	criteria.getQueryOptions().applyToFind(findOpts)
+	return findOpts
+}
+
 func (criteria *criteriaStruct) toBsonD() bson.D {
 	switch criteria.criteriaType {
 	case whereCriteria:
@@ -47,22 +186,50 @@ func (criteria *criteriaStruct) toBsonD() bson.D {
 		}
 		return criteria.thisQueryBsonD
 	case findCriteria:
-		panic("findCriteria.toBsonD") // TODO implement this
+		return criteriaFindToBsonD(criteria)
+	case orCriteria, anyOfCriteria:
+		return criteriaLogicalToBsonD("$or", criteria)
+	case norCriteria:
+		return criteriaLogicalToBsonD("$nor", criteria)
 	}
 	return bson.D{}
 }
 
-////////////////////////////////////////////////////////////////////////////////
-////////////////////////////////////////////////////////////////////////////////
+// combines the given filters into a single filter that matches only when all of the given filters match.
+// Empty filters are ignored. When no field is repeated across the given filters, the filter elements are simply concatenated,
+// otherwise each filter becomes a separate entry within an $and array so that none of the conditions are lost.
+func mergeBsonDFilters(filters ...bson.D) bson.D {
+	nonEmpty := make([]bson.D, 0)
+	for _, filter := range filters {
+		if len(filter) > 0 {
+			nonEmpty = append(nonEmpty, filter)
+		}
+	}
+	switch len(nonEmpty) {
+	case 0:
+		return bson.D{}
+	case 1:
+		return nonEmpty[0]
+	}
 
-// func (criteria *ModelType) First() {
-// }
-// func (criteria *Criteria) First() {
-// }
-// func (criteria *ModelType) Last() {
-// }
-// func (criteria *Criteria) Last() {
-// }
+	merged := bson.D{}
+	seenKeys := make(map[string]bool)
+	for _, filter := range nonEmpty {
+		for _, element := range filter {
+			if seenKeys[element.Key] {
+				// a repeated key would overwrite an earlier condition, so fall back to an explicit $and
+				andA := bson.A{}
+				for _, filter := range nonEmpty {
+					andA = append(andA, filter)
+				}
+				return bson.D{{Key: "$and", Value: andA}}
+			}
+			seenKeys[element.Key] = true
+			merged = append(merged, element)
+		}
+	}
+	return merged
+}
 
 ////////////////////////////////////////////////////////////////////////////////
 ////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,135 @@
+package mongoid
+
+import (
+	"fmt"
+	mongoidError "mongoid/errors"
+	"mongoid/log"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Sum returns the total of the given field across the collection
+func (model *ModelType) Sum(field string) interface{} {
+	log.Debugf("%v.Sum(%v)", model.GetModelName(), field)
+	return criteriaWhere(model, nil).(*criteriaStruct).Sum(field)
+}
+
+// Sum returns the total of the given field across the documents matching the criteria, as calculated by the database server.
+// The total is converted into the Go type of the matching struct field.
+// When no documents match, the zero value of the field type is returned.
+// Sum will panic with an InvalidOperation error for fields that are not stored as numbers (ie, strings, and uint64 and complex fields, which are stored as strings).
+func (criteria *criteriaStruct) Sum(field string) interface{} {
+	log.Debug("Criteria.Sum ", field)
+	return criteria.calculate("Criteria.Sum", "$sum", field, true)
+}
+
+// Avg returns the average of the given field across the collection
+func (model *ModelType) Avg(field string) interface{} {
+	log.Debugf("%v.Avg(%v)", model.GetModelName(), field)
+	return criteriaWhere(model, nil).(*criteriaStruct).Avg(field)
+}
+
+// Avg returns the average of the given field across the documents matching the criteria, as calculated by the database server.
+// The average is always a float64 (even for integer fields), and is nil when no documents match.
+// Avg will panic with an InvalidOperation error for fields that are not stored as numbers (ie, strings, and uint64 and complex fields, which are stored as strings).
+func (criteria *criteriaStruct) Avg(field string) interface{} {
+	log.Debug("Criteria.Avg ", field)
+	return criteria.calculate("Criteria.Avg", "$avg", field, false)
+}
+
+// Min returns the lowest value of the given field across the collection
+func (model *ModelType) Min(field string) interface{} {
+	log.Debugf("%v.Min(%v)", model.GetModelName(), field)
+	return criteriaWhere(model, nil).(*criteriaStruct).Min(field)
+}
+
+// Min returns the lowest value of the given field across the documents matching the criteria, as determined by the database server.
+// The value is converted into the Go type of the matching struct field, and is nil when no documents match.
+func (criteria *criteriaStruct) Min(field string) interface{} {
+	log.Debug("Criteria.Min ", field)
+	return criteria.calculate("Criteria.Min", "$min", field, true)
+}
+
+// Max returns the highest value of the given field across the collection
+func (model *ModelType) Max(field string) interface{} {
+	log.Debugf("%v.Max(%v)", model.GetModelName(), field)
+	return criteriaWhere(model, nil).(*criteriaStruct).Max(field)
+}
+
+// Max returns the highest value of the given field across the documents matching the criteria, as determined by the database server.
+// The value is converted into the Go type of the matching struct field, and is nil when no documents match.
+func (criteria *criteriaStruct) Max(field string) interface{} {
+	log.Debug("Criteria.Max ", field)
+	return criteria.calculate("Criteria.Max", "$max", field, true)
+}
+
+// runs an aggregation that groups every document matching the criteria using the given accumulator operator on the given field.
+// When typed is true, the result is converted into the Go type of the matching struct field.
+func (criteria *criteriaStruct) calculate(methodName, operator, field string, typed bool) interface{} {
+	model := criteria.getExecutionModel(methodName)
+	bsonPath, fieldType, _ := model.getBsonFieldPathType(field)
+	if (operator == "$sum" || operator == "$avg") && fieldType != nil && !isStoredAsNumber(fieldType) {
+		log.Panic(mongoidError.InvalidOperation{
+			MethodName: methodName,
+			Reason:     fmt.Sprintf("field '%v' of type %v is not stored as a number", field, fieldType),
+		})
+	}
+
+	pipeline := append(criteria.getPipelineBsonA(), bson.D{{Key: "$group", Value: bson.D{
+		{Key: "_id", Value: nil},
+		{Key: "value", Value: bson.D{{Key: operator, Value: "$" + bsonPath}}},
+	}}})
+
+	ctx := model.GetClient().Context()
+	collection := model.getReadCollectionHandle(criteria.getQueryOptions())
+	log.Debugf("collection[%s].Aggregate %v", collection.Name(), pipeline)
+	aggregateOpts := options.Aggregate()
+	criteria.getQueryOptions().applyToAggregate(aggregateOpts)
+	cur, err := collection.Aggregate(ctx, pipeline, aggregateOpts)
+	if err != nil {
+		log.Panic(err) // unknown bad stuff happened within the driver
+	}
+	defer cur.Close(ctx)
+
+	var value interface{}
+	if cur.Next(ctx) {
+		var groupM bson.M
+		if err := cur.Decode(&groupM); err != nil {
+			log.Panic(err)
+		}
+		value = groupM["value"]
+	} else if err := cur.Err(); err != nil {
+		log.Panic(err)
+	} else if operator == "$sum" && fieldType != nil {
+		// nothing matched, but a sum of nothing is still zero
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		return reflect.Zero(fieldType).Interface()
+	}
+
+	if !typed {
+		return value
+	}
+	return marshalFieldValueFromDB(fieldType, value)
+}
+
+// builds the leading aggregation pipeline stages ($match, $sort, $skip, $limit) that select the same documents as the criteria chain
+func (criteria *criteriaStruct) getPipelineBsonA() bson.A {
+	pipeline := bson.A{}
+	if filter := criteria.getFilterBsonD(); len(filter) > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: filter}})
+	}
+	if sortD := criteria.getSortBsonD(); len(sortD) > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$sort", Value: sortD}})
+	}
+	if skip, found := criteria.getNumber(skipCriteria); found {
+		pipeline = append(pipeline, bson.D{{Key: "$skip", Value: skip}})
+	}
+	if limit, found := criteria.getNumber(limitCriteria); found {
+		pipeline = append(pipeline, bson.D{{Key: "$limit", Value: limit}})
+	}
+	return pipeline
+}
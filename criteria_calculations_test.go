@@ -0,0 +1,76 @@
+package mongoid_test
+
+import (
+	"mongoid"
+	mongoidError "mongoid/errors"
+	"mongoid/log"
+	"time"
+
+	gofakeit "github.com/brianvoe/gofakeit/v6"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Criteria", func() {
+	Context(".Sum() .Avg() .Min() .Max()", func() {
+		It("calculate the field across the matching records", func() {
+			OnlineDatabaseOnly(func() {
+				group := gofakeit.UUID()
+				createCriteriaTestModel("a", group, 1)
+				createCriteriaTestModel("b", group, 2)
+				createCriteriaTestModel("c", group, 6)
+				criteria := CriteriaTestModels.Where(mongoid.Q{"group": group})
+				Expect(criteria.Sum("Number")).To(Equal(9))
+				Expect(criteria.Avg("Number")).To(Equal(float64(3)))
+				Expect(criteria.Min("Number")).To(Equal(1))
+				Expect(criteria.Max("number")).To(Equal(6))
+			})
+		})
+		It("return zero or nil when nothing matches", func() {
+			OnlineDatabaseOnly(func() {
+				criteria := CriteriaTestModels.Where(mongoid.Q{"group": gofakeit.UUID()})
+				Expect(criteria.Sum("Number")).To(Equal(0))
+				Expect(criteria.Avg("Number")).To(BeNil())
+				Expect(criteria.Min("Number")).To(BeNil())
+				Expect(criteria.Max("Number")).To(BeNil())
+			})
+		})
+		It("panic with InvalidOperation when summing fields that are not stored as numbers", func() {
+			type CalculationTestModel struct {
+				mongoid.Base `mongoid:"collection:calculation_test_models"`
+				ID           mongoid.ObjectID `bson:"_id"`
+				Big          uint64
+			}
+			CalculationTestModels := mongoid.Register(&CalculationTestModel{})
+			Expect(func() {
+				log.WithMute(func() { CalculationTestModels.Sum("Big") })
+			}).To(PanicWith(BeAssignableToTypeOf(mongoidError.InvalidOperation{})))
+			Expect(func() {
+				log.WithMute(func() { CalculationTestModels.Avg("Big") })
+			}).To(PanicWith(BeAssignableToTypeOf(mongoidError.InvalidOperation{})))
+		})
+		It("return time.Time values for Min and Max of time fields", func() {
+			OnlineDatabaseOnly(func() {
+				type CalculationTimeTestModel struct {
+					mongoid.Base `mongoid:"collection:calculation_time_test_models"`
+					ID           mongoid.ObjectID `bson:"_id"`
+					Group        string
+					At           time.Time
+				}
+				CalculationTimeTestModels := mongoid.Register(&CalculationTimeTestModel{})
+				group := gofakeit.UUID()
+				first := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+				last := first.Add(time.Hour)
+				for _, at := range []time.Time{last, first} {
+					doc := CalculationTimeTestModels.New().(*CalculationTimeTestModel)
+					doc.Group = group
+					doc.At = at
+					Expect(doc.Save()).To(Succeed())
+				}
+				criteria := CalculationTimeTestModels.Where(mongoid.Q{"group": group})
+				Expect(criteria.Min("At")).To(Equal(first))
+				Expect(criteria.Max("At")).To(Equal(last))
+			})
+		})
+	})
+})
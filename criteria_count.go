@@ -0,0 +1,92 @@
+package mongoid
+
+import (
+	mongoidError "mongoid/errors"
+	"mongoid/log"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Count returns the number of documents within the collection, as counted by the database server
+func (model *ModelType) Count() int64 {
+	log.Debugf("%v.Count()", model.GetModelName())
+	return criteriaWhere(model, nil).(*criteriaStruct).Count()
+}
+
+// Count returns the number of documents matching the criteria, as counted by the database server.
+// Unlike Result.Count, no documents are read in order to count them. Any Skip or Limit within the criteria is honored.
+func (criteria *criteriaStruct) Count() int64 {
+	model := criteria.getExecutionModel("Criteria.Count")
+	log.Debugf("%v.Criteria.Count()", model.GetModelName())
+
+	countOpts := options.Count()
+	if limit, found := criteria.getNumber(limitCriteria); found {
+		countOpts.SetLimit(limit)
+	}
+	if skip, found := criteria.getNumber(skipCriteria); found {
+		countOpts.SetSkip(skip)
+	}
+	criteria.getQueryOptions().applyToCount(countOpts)
+
+	collection := model.getReadCollectionHandle(criteria.getQueryOptions())
+	count, err := collection.CountDocuments(model.GetClient().Context(), criteria.getFilterBsonD(), countOpts)
+	if err != nil {
+		log.Panic(err) // unknown bad stuff happened within the driver
+	}
+	return count
+}
+
+// Exists returns true if the collection contains at least one document
+func (model *ModelType) Exists() bool {
+	log.Debugf("%v.Exists()", model.GetModelName())
+	return criteriaWhere(model, nil).(*criteriaStruct).Exists()
+}
+
+// Exists returns true if at least one document matches the criteria.
+// Only the _id of a single document is ever requested from the database server.
+func (criteria *criteriaStruct) Exists() bool {
+	model := criteria.getExecutionModel("Criteria.Exists")
+	log.Debugf("%v.Criteria.Exists()", model.GetModelName())
+
+	findOpts := options.FindOne().SetProjection(map[string]int32{"_id": 1})
+	if skip, found := criteria.getNumber(skipCriteria); found {
+		findOpts.SetSkip(skip)
+	}
+	criteria.getQueryOptions().applyToFindOne(findOpts)
+
+	collection := model.getReadCollectionHandle(criteria.getQueryOptions())
+	err := collection.FindOne(model.GetClient().Context(), criteria.getFilterBsonD(), findOpts).Err()
+	if err == nil {
+		return true
+	}
+	if err == mongo.ErrNoDocuments {
+		return false
+	}
+	log.Panic(err) // unknown bad stuff happened within the driver
+	return false
+}
+
+// EstimatedCount returns the approximate number of documents within the collection, using the collection metadata rather than
+// scanning the collection. The value is faster to get than Count, but may be inaccurate (ie, after an unclean shutdown).
+func (model *ModelType) EstimatedCount() int64 {
+	log.Debugf("%v.EstimatedCount()", model.GetModelName())
+	collection := model.getMongoCollectionHandle()
+	count, err := collection.EstimatedDocumentCount(model.GetClient().Context())
+	if err != nil {
+		log.Panic(err) // unknown bad stuff happened within the driver
+	}
+	return count
+}
+
+// returns the ModelType the criteria chain was started from, panicking with an InvalidOperation on behalf of methodName if there isn't one
+func (criteria *criteriaStruct) getExecutionModel(methodName string) *ModelType {
+	model := criteria.getSourceModel()
+	if model == nil {
+		log.Panic(mongoidError.InvalidOperation{
+			MethodName: methodName,
+			Reason:     "Criteria is not associated with a ModelType",
+		})
+	}
+	return model
+}
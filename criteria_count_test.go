@@ -0,0 +1,68 @@
+package mongoid_test
+
+import (
+	"mongoid"
+
+	gofakeit "github.com/brianvoe/gofakeit/v6"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Criteria", func() {
+	Context(".Count()", func() {
+		It("counts the matching records", func() {
+			OnlineDatabaseOnly(func() {
+				group := gofakeit.UUID()
+				createCriteriaTestModel("one", group, 1)
+				createCriteriaTestModel("two", group, 2)
+				createCriteriaTestModel("three", group, 3)
+				Expect(CriteriaTestModels.Where(mongoid.Q{"group": group}).Count()).To(Equal(int64(3)))
+				Expect(CriteriaTestModels.Where(mongoid.Q{"group": group, "number.$gt": 1}).Count()).To(Equal(int64(2)))
+			})
+		})
+		It("honors Skip and Limit", func() {
+			OnlineDatabaseOnly(func() {
+				group := gofakeit.UUID()
+				for i := 1; i <= 5; i++ {
+					createCriteriaTestModel(gofakeit.HipsterWord(), group, i)
+				}
+				Expect(CriteriaTestModels.Where(mongoid.Q{"group": group}).Limit(2).Count()).To(Equal(int64(2)))
+				Expect(CriteriaTestModels.Where(mongoid.Q{"group": group}).Skip(4).Count()).To(Equal(int64(1)))
+			})
+		})
+		It("returns zero when nothing matches", func() {
+			OnlineDatabaseOnly(func() {
+				Expect(CriteriaTestModels.Where(mongoid.Q{"group": gofakeit.UUID()}).Count()).To(Equal(int64(0)))
+			})
+		})
+	})
+
+	Context(".Exists()", func() {
+		It("is true when a record matches", func() {
+			OnlineDatabaseOnly(func() {
+				group := gofakeit.UUID()
+				createCriteriaTestModel("one", group, 1)
+				Expect(CriteriaTestModels.Where(mongoid.Q{"group": group}).Exists()).To(BeTrue())
+				Expect(CriteriaTestModels.Exists()).To(BeTrue())
+			})
+		})
+		It("is false when nothing matches", func() {
+			OnlineDatabaseOnly(func() {
+				Expect(CriteriaTestModels.Where(mongoid.Q{"group": gofakeit.UUID()}).Exists()).To(BeFalse())
+			})
+		})
+	})
+})
+
+var _ = Describe("ModelType", func() {
+	Context(".Count() and .EstimatedCount()", func() {
+		It("count every record within the collection", func() {
+			OnlineDatabaseOnly(func() {
+				createCriteriaTestModel("one", gofakeit.UUID(), 1)
+				count := CriteriaTestModels.Count()
+				Expect(count).To(BeNumerically(">=", 1))
+				Expect(CriteriaTestModels.EstimatedCount()).To(BeNumerically(">=", 1))
+			})
+		})
+	})
+})
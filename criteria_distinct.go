@@ -0,0 +1,149 @@
+package mongoid
+
+import (
+	"mongoid/log"
+	"mongoid/util"
+	"reflect"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Distinct returns the unique values of the given field across the collection
+func (model *ModelType) Distinct(field string) []interface{} {
+	log.Debugf("%v.Distinct(%v)", model.GetModelName(), field)
+	return criteriaWhere(model, nil).(*criteriaStruct).Distinct(field)
+}
+
+// Distinct returns the unique values of the given field across the documents matching the criteria, as determined by the database server.
+// The field may be given as either the Go struct field name or the bson field name, and dotted paths reach into embedded documents.
+// When the field is an array, the unique values are the individual array elements.
+// Values are converted into the Go type of the matching struct field, when one is known.
+func (criteria *criteriaStruct) Distinct(field string) []interface{} {
+	model := criteria.getExecutionModel("Criteria.Distinct")
+	log.Debugf("%v.Criteria.Distinct(%v)", model.GetModelName(), field)
+
+	bsonPath, fieldType, _ := model.getBsonFieldPathType(field)
+	if fieldType != nil && (fieldType.Kind() == reflect.Slice || fieldType.Kind() == reflect.Array) && fieldType.Elem().Kind() != reflect.Uint8 {
+		fieldType = fieldType.Elem() // the server unwinds arrays, so each value is an element
+	}
+
+	collection := model.getReadCollectionHandle(criteria.getQueryOptions())
+	distinctOpts := options.Distinct()
+	criteria.getQueryOptions().applyToDistinct(distinctOpts)
+	values, err := collection.Distinct(model.GetClient().Context(), bsonPath, criteria.getFilterBsonD(), distinctOpts)
+	if err != nil {
+		log.Panic(err) // unknown bad stuff happened within the driver
+	}
+	for i := range values {
+		values[i] = marshalFieldValueFromDB(fieldType, values[i])
+	}
+	return values
+}
+
+// Pluck returns the values of the given fields for every document in the collection
+func (model *ModelType) Pluck(fields ...string) [][]interface{} {
+	log.Debugf("%v.Pluck(%v)", model.GetModelName(), fields)
+	return criteriaWhere(model, nil).(*criteriaStruct).Pluck(fields...)
+}
+
+// Pluck returns the values of the given fields for each document matching the criteria, without building any document objects.
+// Each entry of the returned slice holds the values of one document, in the same order as the given fields, and missing fields are nil.
+// Only the given fields are requested from the database server, and any Sort, Skip or Limit within the criteria is honored.
+// Values are converted into the Go type of the matching struct field, when one is known.
+func (criteria *criteriaStruct) Pluck(fields ...string) [][]interface{} {
+	model := criteria.getExecutionModel("Criteria.Pluck")
+	log.Debugf("%v.Criteria.Pluck(%v)", model.GetModelName(), fields)
+
+	bsonPaths := make([]string, len(fields))
+	fieldTypes := make([]reflect.Type, len(fields))
+	projection := bson.D{}
+	includesID := false
+	for i, field := range fields {
+		bsonPaths[i], fieldTypes[i], _ = model.getBsonFieldPathType(field)
+		projection = append(projection, bson.E{Key: bsonPaths[i], Value: int32(1)})
+		includesID = includesID || bsonPaths[i] == "_id"
+	}
+	if !includesID {
+		projection = append(projection, bson.E{Key: "_id", Value: int32(0)})
+	}
+	findOpts := criteria.getFindOptions()
+	findOpts.SetProjection(projection)
+
+	ctx := model.GetClient().Context()
+	collection := model.getReadCollectionHandle(criteria.getQueryOptions())
+	cur, err := collection.Find(ctx, criteria.getFilterBsonD(), findOpts)
+	if err != nil {
+		log.Panic(err) // unknown bad stuff happened within the driver
+	}
+	defer cur.Close(ctx)
+
+	plucked := make([][]interface{}, 0)
+	for cur.Next(ctx) {
+		var bsonM bson.M
+		if err := cur.Decode(&bsonM); err != nil {
+			log.Panic(err)
+		}
+		row := make([]interface{}, len(fields))
+		for i, bsonPath := range bsonPaths {
+			row[i] = marshalFieldValueFromDB(fieldTypes[i], getBsonMPathValue(bsonM, bsonPath))
+		}
+		plucked = append(plucked, row)
+	}
+	if err := cur.Err(); err != nil {
+		log.Panic(err)
+	}
+	return plucked
+}
+
+// returns the value at the given dotted path within bsonM, or nil if any part of the path is missing
+func getBsonMPathValue(bsonM bson.M, path string) interface{} {
+	var cur interface{} = bsonM
+	for _, segment := range strings.Split(path, ".") {
+		switch curV := cur.(type) {
+		case bson.M:
+			cur = curV[segment]
+		case bson.D:
+			cur = curV.Map()[segment]
+		default:
+			return nil
+		}
+	}
+	return cur
+}
+
+// converts a value read from the database into the given Go fieldType, for the kinds of values that are stored differently than they are declared
+// (ie, an int field is stored as int32, a uint64 field is stored as a string, a time.Time field is read as a primitive.DateTime).
+// Numbers are converted the same way as document fields are (see util.MarshalFromDB).
+// Anything else is returned unaltered, as is any value when fieldType is unknown, or when the value does not fit the fieldType
+// (ie, a float64 or negative number written into a uint field by another application).
+func marshalFieldValueFromDB(fieldType reflect.Type, value interface{}) interface{} {
+	if fieldType == nil || value == nil {
+		return value
+	}
+	for fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+	if reflect.TypeOf(value) == fieldType {
+		return value
+	}
+	if dateTime, ok := value.(primitive.DateTime); ok && fieldType == reflect.TypeOf(time.Time{}) {
+		return dateTime.Time().UTC()
+	}
+	switch fieldType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Complex64, reflect.Complex128:
+		if converted, err := util.TryMarshalFromDB(fieldType, value); err == nil {
+			return converted
+		}
+	case reflect.Float32:
+		if f, ok := value.(float64); ok {
+			return reflect.ValueOf(f).Convert(fieldType).Interface()
+		}
+	}
+	return value
+}
@@ -0,0 +1,38 @@
+package mongoid_test
+
+import (
+	"mongoid"
+
+	gofakeit "github.com/brianvoe/gofakeit/v6"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Criteria", func() {
+	Context(".Distinct()", func() {
+		It("returns each unique value of the field once, typed as the struct field", func() {
+			OnlineDatabaseOnly(func() {
+				group := gofakeit.UUID()
+				createCriteriaTestModel("a", group, 1)
+				createCriteriaTestModel("b", group, 1)
+				createCriteriaTestModel("c", group, 2)
+				Expect(CriteriaTestModels.Where(mongoid.Q{"group": group}).Distinct("Number")).To(ConsistOf(1, 2))
+			})
+		})
+	})
+
+	Context(".Pluck()", func() {
+		It("returns only the requested field values of each record", func() {
+			OnlineDatabaseOnly(func() {
+				group := gofakeit.UUID()
+				createCriteriaTestModel("a", group, 1)
+				createCriteriaTestModel("b", group, 2)
+				plucked := CriteriaTestModels.Where(mongoid.Q{"group": group}).Asc("Number").Pluck("Name", "number", "missing")
+				Expect(plucked).To(Equal([][]interface{}{
+					{"a", 1, nil},
+					{"b", 2, nil},
+				}))
+			})
+		})
+	})
+})
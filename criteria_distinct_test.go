@@ -0,0 +1,65 @@
+package mongoid
+
+import (
+	"reflect"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Criteria Distinct and Pluck", func() {
+
+	Describe("getBsonMPathValue", func() {
+		doc := bson.M{
+			"name":    "top",
+			"address": bson.M{"city": "Springfield"},
+			"other":   bson.D{{Key: "zip", Value: "12345"}},
+		}
+		It("finds top-level values", func() {
+			Expect(getBsonMPathValue(doc, "name")).To(Equal("top"))
+		})
+		It("finds values within embedded documents", func() {
+			Expect(getBsonMPathValue(doc, "address.city")).To(Equal("Springfield"))
+			Expect(getBsonMPathValue(doc, "other.zip")).To(Equal("12345"))
+		})
+		It("returns nil for missing paths", func() {
+			Expect(getBsonMPathValue(doc, "missing")).To(BeNil())
+			Expect(getBsonMPathValue(doc, "address.missing")).To(BeNil())
+			Expect(getBsonMPathValue(doc, "name.deeper")).To(BeNil())
+		})
+	})
+
+	Describe("marshalFieldValueFromDB", func() {
+		It("converts stored values into the Go field type", func() {
+			Expect(marshalFieldValueFromDB(reflect.TypeOf(int(0)), int32(5))).To(Equal(int(5)))
+			Expect(marshalFieldValueFromDB(reflect.TypeOf(uint64(0)), "18446744073709551615")).To(Equal(uint64(18446744073709551615)))
+			Expect(marshalFieldValueFromDB(reflect.TypeOf(float32(0)), float64(1.5))).To(Equal(float32(1.5)))
+			when := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+			Expect(marshalFieldValueFromDB(reflect.TypeOf(when), primitive.NewDateTimeFromTime(when))).To(Equal(when))
+		})
+		It("follows pointer field types", func() {
+			var ptr *int
+			Expect(marshalFieldValueFromDB(reflect.TypeOf(ptr), int32(5))).To(Equal(int(5)))
+		})
+		It("returns the value unaltered when no conversion applies", func() {
+			Expect(marshalFieldValueFromDB(nil, int32(5))).To(Equal(int32(5)))
+			Expect(marshalFieldValueFromDB(reflect.TypeOf(""), "text")).To(Equal("text"))
+			Expect(marshalFieldValueFromDB(reflect.TypeOf(int(0)), float64(2.5))).To(Equal(float64(2.5)))
+			Expect(marshalFieldValueFromDB(reflect.TypeOf(int(0)), nil)).To(BeNil())
+		})
+		It("returns the value unaltered when it does not fit the field type", func() {
+			Expect(marshalFieldValueFromDB(reflect.TypeOf(uint(0)), float64(2.5))).To(Equal(float64(2.5)))
+			Expect(marshalFieldValueFromDB(reflect.TypeOf(uint(0)), int32(-1))).To(Equal(int32(-1)))
+			Expect(marshalFieldValueFromDB(reflect.TypeOf(uint64(0)), "not a number")).To(Equal("not a number"))
+			Expect(marshalFieldValueFromDB(reflect.TypeOf(int8(0)), int32(300))).To(Equal(int32(300)))
+			Expect(marshalFieldValueFromDB(reflect.TypeOf(complex128(0)), true)).To(Equal(true))
+		})
+		It("converts stored complex numbers", func() {
+			Expect(marshalFieldValueFromDB(reflect.TypeOf(complex128(0)), "(1+2i)")).To(Equal(complex128(1 + 2i)))
+		})
+	})
+})
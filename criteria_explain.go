@@ -0,0 +1,116 @@
+package mongoid
+
+import (
+	"mongoid/log"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ExplainVerbosity is the level of detail requested from Criteria.Explain
+type ExplainVerbosity string
+
+// Verbosity levels of the explain command
+const (
+	ExplainQueryPlanner      ExplainVerbosity = "queryPlanner"      // only the plan chosen by the query planner, without running the query
+	ExplainExecutionStats    ExplainVerbosity = "executionStats"    // also runs the winning plan, reporting the documents and keys examined
+	ExplainAllPlansExecution ExplainVerbosity = "allPlansExecution" // also reports the execution of the plans that were rejected
+)
+
+// ExplainResult is the report from Criteria.Explain of how the database server runs the query of the criteria.
+// DocsExamined, KeysExamined and DocsReturned are only reported with ExplainExecutionStats or ExplainAllPlansExecution verbosity.
+type ExplainResult struct {
+	WinningStage string // the stage at the root of the winning plan (ie, FETCH, IXSCAN, COLLSCAN, SORT)
+	IndexName    string // the name of the index used by the winning plan, empty if no index was used
+	IsCollScan   bool   // true if the winning plan scans the entire collection
+	DocsExamined int64
+	KeysExamined int64
+	DocsReturned int64
+	Raw          bson.M // the complete response of the explain command
+}
+
+// Explain runs the explain command for the find of the criteria, reporting the winning plan of the query and which index it uses (if any).
+func (criteria *criteriaStruct) Explain(verbosity ExplainVerbosity) (*ExplainResult, error) {
+	model := criteria.getExecutionModel("Criteria.Explain")
+	log.Debugf("%v.Criteria.Explain(%v)", model.GetModelName(), verbosity)
+
+	collection := model.getMongoCollectionHandle()
+	commandD := bson.D{
+		{Key: "explain", Value: criteria.getExplainFindBsonD(collection.Name())},
+		{Key: "verbosity", Value: string(verbosity)},
+	}
+	runCmdOpts := options.RunCmd() // commands are not sent according to the read preference of the collection, so it must be given
+	if readPreference := model.getReadPreference(criteria.getQueryOptions()); readPreference != nil {
+		runCmdOpts.SetReadPreference(readPreference)
+	}
+	var raw bson.M
+	if err := collection.Database().RunCommand(model.GetClient().Context(), commandD, runCmdOpts).Decode(&raw); err != nil {
+		return nil, err
+	}
+	return makeExplainResult(raw), nil
+}
+
+// the find command for the criteria, which shares its field names with the query of ToBsonD, along with any options that change the query plan
+func (criteria *criteriaStruct) getExplainFindBsonD(collectionName string) bson.D {
+	findD := append(bson.D{{Key: "find", Value: collectionName}}, criteria.getQueryBsonD()...)
+	return append(findD, criteria.getQueryOptions().findCommandBsonD()...)
+}
+
+// parses the response of the explain command
+func makeExplainResult(raw bson.M) *ExplainResult {
+	result := &ExplainResult{Raw: raw}
+	queryPlanner := explainDocument(raw["queryPlanner"])
+	winningPlan := explainDocument(queryPlanner["winningPlan"])
+	if queryPlan, found := winningPlan["queryPlan"]; found { // servers using the slot based engine wrap the plan
+		winningPlan = explainDocument(queryPlan)
+	}
+	result.WinningStage, _ = winningPlan["stage"].(string)
+	result.walkExplainStages(winningPlan)
+
+	executionStats := explainDocument(raw["executionStats"])
+	result.DocsExamined = explainInt64(executionStats["totalDocsExamined"])
+	result.KeysExamined = explainInt64(executionStats["totalKeysExamined"])
+	result.DocsReturned = explainInt64(executionStats["nReturned"])
+	return result
+}
+
+// visits the given stage and every stage beneath it, recording the index used and whether the collection is scanned
+func (result *ExplainResult) walkExplainStages(stage bson.M) {
+	if stage["stage"] == "COLLSCAN" {
+		result.IsCollScan = true
+	}
+	if indexName, ok := stage["indexName"].(string); ok && result.IndexName == "" {
+		result.IndexName = indexName
+	}
+	if inputStage, found := stage["inputStage"]; found {
+		result.walkExplainStages(explainDocument(inputStage))
+	}
+	inputStages, _ := stage["inputStages"].(bson.A)
+	for _, inputStage := range inputStages {
+		result.walkExplainStages(explainDocument(inputStage))
+	}
+}
+
+// reads an embedded document of the explain response, which may have been decoded as either bson.M or bson.D
+func explainDocument(value interface{}) bson.M {
+	switch v := value.(type) {
+	case bson.M:
+		return v
+	case bson.D:
+		return v.Map()
+	}
+	return bson.M{}
+}
+
+// reads a count of the explain response, which may have been given as any numeric type
+func explainInt64(value interface{}) int64 {
+	switch v := value.(type) {
+	case int32:
+		return int64(v)
+	case int64:
+		return v
+	case float64:
+		return int64(v)
+	}
+	return 0
+}
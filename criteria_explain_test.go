@@ -0,0 +1,64 @@
+package mongoid
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Criteria Explain", func() {
+	It("explains the find of the criteria", func() {
+		criteria := newCriteriaRoot(nil).Where(Q{"name": "bob"}).Desc("age").Limit(2).(*criteriaStruct)
+		Expect(criteria.getExplainFindBsonD("people")).To(Equal(bson.D{
+			{Key: "find", Value: "people"},
+			{Key: "filter", Value: bson.D{{Key: "name", Value: "bob"}}},
+			{Key: "sort", Value: bson.D{{Key: "age", Value: int32(-1)}}},
+			{Key: "limit", Value: int64(2)},
+		}))
+	})
+
+	Describe("makeExplainResult", func() {
+		It("reports the index used by the winning plan", func() {
+			raw := bson.M{
+				"queryPlanner": bson.M{
+					"winningPlan": bson.M{
+						"stage":      "FETCH",
+						"inputStage": bson.M{"stage": "IXSCAN", "indexName": "name_1"},
+					},
+				},
+				"executionStats": bson.M{"nReturned": int32(3), "totalDocsExamined": int32(3), "totalKeysExamined": int64(4)},
+			}
+			result := makeExplainResult(raw)
+			Expect(result.WinningStage).To(Equal("FETCH"))
+			Expect(result.IndexName).To(Equal("name_1"))
+			Expect(result.IsCollScan).To(BeFalse())
+			Expect(result.DocsReturned).To(Equal(int64(3)))
+			Expect(result.DocsExamined).To(Equal(int64(3)))
+			Expect(result.KeysExamined).To(Equal(int64(4)))
+			Expect(result.Raw).To(Equal(raw))
+		})
+		It("reports collection scans, including those nested within other stages", func() {
+			result := makeExplainResult(bson.M{
+				"queryPlanner": bson.D{{Key: "winningPlan", Value: bson.D{
+					{Key: "stage", Value: "SORT"},
+					{Key: "inputStages", Value: bson.A{bson.D{{Key: "stage", Value: "COLLSCAN"}}}},
+				}}},
+			})
+			Expect(result.WinningStage).To(Equal("SORT"))
+			Expect(result.IndexName).To(BeEmpty())
+			Expect(result.IsCollScan).To(BeTrue())
+			Expect(result.DocsExamined).To(Equal(int64(0)))
+		})
+		It("unwraps the plans of the slot based engine", func() {
+			result := makeExplainResult(bson.M{
+				"queryPlanner": bson.M{"winningPlan": bson.M{
+					"queryPlan":     bson.M{"stage": "IXSCAN", "indexName": "_id_"},
+					"slotBasedPlan": bson.M{},
+				}},
+			})
+			Expect(result.WinningStage).To(Equal("IXSCAN"))
+			Expect(result.IndexName).To(Equal("_id_"))
+		})
+	})
+})
@@ -1,59 +1,77 @@
 package mongoid
 
-// import (
-// 	"mongoid/log"
-// 	"strconv"
+import (
+	mongoidError "mongoid/errors"
+	"mongoid/log"
 
-// 	"go.mongodb.org/mongo-driver/bson"
-// )
+	"go.mongodb.org/mongo-driver/bson"
+)
 
-// // Find a document or multiple documents by their ids
-// func (model *ModelType) Find(ids ...ObjectID) Criteria {
-// 	log.Debug("ModelType.Find ", ids)
-// 	return criteriaFind(nil, ids...)
-// }
+// Find adds criteria that only match the documents with the given ids.
+// Same as Ruby Mongoid, when the criteria is executed via X(), every requested id must be found,
+// otherwise X() will panic with an IDsNotFound error listing the MissingIDs.
+// Note that an id excluded by a Skip or Limit within the same criteria is also considered missing.
+func (criteria *criteriaStruct) Find(ids ...ObjectID) Criteria {
+	log.Debug("Criteria.Find ", ids)
+	return criteriaFind(criteria, ids...)
+}
 
-// // Find a document or multiple documents by their ids
-// func (criteria *criteriaStruct) Find(ids ...ObjectID) Criteria {
-// 	log.Debug("Criteria.Find ", ids)
-// 	return criteriaFind(criteria, ids...)
-// }
+func criteriaFind(prevCriteria *criteriaStruct, ids ...ObjectID) Criteria {
+	log.Traceln("New Criteria.Find ", ids)
+	newCriteria := criteriaStruct{
+		criteriaType: findCriteria,
+		prevCriteria: prevCriteria,
+		thisIDs:      ids,
+	}
+	return &newCriteria
+}
 
-// func criteriaFind(prevCriteria *criteriaStruct, ids ...ObjectID) Criteria {
-// 	// log.Print(ids)
+func criteriaFindToBsonD(find *criteriaStruct) bson.D {
+	switch len(find.thisIDs) {
+	case 0:
+		return bson.D{}
+	case 1:
+		return bson.D{{Key: "_id", Value: find.thisIDs[0]}}
+	}
+	return bson.D{{Key: "_id", Value: bson.M{"$in": find.thisIDs}}}
+}
 
-// 	thisQuery := Query{}
-// 	for k, v := range ids {
-// 		thisQuery[strconv.Itoa(k)] = v
-// 	}
-// 	log.Traceln("New Criteria.Find ", thisQuery)
-// 	newCriteria := criteriaStruct{
-// 		criteriaType: findCriteria,
-// 		prevCriteria: prevCriteria,
-// 		thisQuery:    thisQuery,
-// 	}
-// 	// log.Printf("%+v\n", newCriteria)
-// 	return &newCriteria
-// }
+// returns every id requested by a Find within the criteria chain, without duplicates
+func (criteria *criteriaStruct) getFindIDs() []ObjectID {
+	ids := make([]ObjectID, 0)
+	seen := make(map[ObjectID]bool)
+	for _, link := range criteria.getChain() {
+		if link.criteriaType != findCriteria {
+			continue
+		}
+		for _, id := range link.thisIDs {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
 
-// func criteriaFindToBsonD(find *criteriaStruct) bson.D {
-// 	var idsArray bson.A
-// 	for _, k := range find.thisQuery {
-// 		objectID := k.(ObjectID)
-// 		idsArray = append(idsArray, objectID)
-// 	}
-// 	var bsonD bson.D
-// 	switch len(idsArray) {
-// 	case 0:
-// 		bsonD = bson.D{}
-// 	case 1:
-// 		bsonD = bson.D{{Key: "_id", Value: idsArray[0]}}
-// 	default:
-// 		bsonA := bson.A{}
-// 		for _, v := range idsArray {
-// 			bsonA = append(bsonA, bson.M{"_id": v})
-// 		}
-// 		bsonD = bson.D{{Key: "$or", Value: bsonA}}
-// 	}
-// 	return bsonD
-// }
+// panics with an IDsNotFound error when any of the ids requested by a Find within the criteria chain are not within the given Result
+func (criteria *criteriaStruct) verifyFindIDs(res *Result) {
+	ids := criteria.getFindIDs()
+	if len(ids) == 0 {
+		return
+	}
+	res.Count() // read everything into the lookback, so the ids can be checked
+	found := make(map[interface{}]bool)
+	for _, bsonM := range res.lookback {
+		found[bsonM["_id"]] = true
+	}
+	missingIDs := make([]interface{}, 0)
+	for _, id := range ids {
+		if !found[id] {
+			missingIDs = append(missingIDs, id)
+		}
+	}
+	if len(missingIDs) > 0 {
+		log.Panic(mongoidError.IDsNotFound{MissingIDs: missingIDs})
+	}
+}
@@ -1,73 +1,55 @@
 package mongoid
 
-// import (
-// 	// "fmt"
-// 	"go.mongodb.org/mongo-driver/bson"
+import (
+	"go.mongodb.org/mongo-driver/bson"
 
-// 	. "github.com/onsi/ginkgo"
-// 	. "github.com/onsi/gomega"
-// )
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
 
-// var _ = Describe("Criteria Building", func() {
-// 	Describe("criteriaFind", func() {
-// 		It("returns a new Criteria pointer", func() {
-// 			Expect(criteriaFind(nil)).To(BeAssignableToTypeOf(&criteriaStruct{}))
-// 		})
-// 		It("accepts an empty criteria input", func() {
-// 			criteriaFind(nil)
-// 		})
-// 		It("accepts an ObjectID criteria input", func() {
-// 			criteriaFind(nil, NewObjectID())
-// 		})
-// 		It("accepts multiple ObjectID criteria input", func() {
-// 			criteriaFind(nil, NewObjectID(), NewObjectID(), NewObjectID(), NewObjectID())
-// 		})
-// 		It("chains to given Criteria", func() {
-// 			existingCriteria := criteriaStruct{}
-// 			newCriteriaPtr := criteriaFind(&existingCriteria)
-// 			Expect(newCriteriaPtr.getPrevCriteria()).To(BeIdenticalTo(&existingCriteria))
-// 		})
-// 		It("builds driver-ready query BSON", func() {
-// 			{
-// 				By("No ObjectIDs")
-// 				criteriaPtr := criteriaFind(nil)
-// 				findPtr := criteriaPtr.(*criteriaStruct)
-// 				bsonResult := criteriaFindToBsonD(findPtr)
-// 				Expect(bsonResult).To(BeAssignableToTypeOf(bson.D{}))
-// 				Expect(len(bsonResult)).To(Equal(0))
-// 			}
-// 			{
-// 				By("One ObjectIDs")
-// 				newObjID := NewObjectID()
-// 				criteriaPtr := criteriaFind(nil, newObjID)
-// 				findPtr := criteriaPtr.(*criteriaStruct)
-// 				bsonResult := criteriaFindToBsonD(findPtr)
-// 				Expect(bsonResult).To(BeAssignableToTypeOf(bson.D{}))
-// 				Expect(len(bsonResult)).To(Equal(1))
-// 				Expect(bsonResult[0].Key).To(Equal("_id"))
-// 				Expect(bsonResult[0].Value).To(Equal(newObjID))
-// 			}
-// 			{
-// 				By("Many ObjectIDs")
-// 				criteriaPtr := criteriaFind(nil, NewObjectID(), NewObjectID(), NewObjectID(), NewObjectID())
-// 				findPtr := criteriaPtr.(*criteriaStruct)
-// 				bsonResult := criteriaFindToBsonD(findPtr)
-// 				Expect(bsonResult).To(BeAssignableToTypeOf(bson.D{}))
-// 				Expect(len(bsonResult)).To(Equal(1))
-// 				Expect(bsonResult[0].Key).To(Equal("$or"))
-// 				Expect(bsonResult[0].Value).To(BeAssignableToTypeOf(bson.A{}))
-// 				Expect(len(bsonResult[0].Value.(bson.A))).To(Equal(4))
-// 			}
-// 		})
-// 	})
-// })
-
-// // func criteriaFind(prevCriteria *Criteria, ids ...ObjectID) *Criteria {
-// // 	thisQuery := Query{}
-// // 	newCriteria := Criteria{
-// // 		prevCriteria: prevCriteria,
-// // 		thisVerb:     queryVerbFind,
-// // 		thisQuery:    thisQuery,
-// // 	}
-// // 	return &newCriteria
-// // }
+var _ = Describe("Criteria Building", func() {
+	Describe("criteriaFind", func() {
+		It("returns a new Criteria pointer", func() {
+			Expect(criteriaFind(nil)).To(BeAssignableToTypeOf(&criteriaStruct{}))
+		})
+		It("chains to given Criteria", func() {
+			existingCriteria := criteriaStruct{}
+			newCriteriaPtr := criteriaFind(&existingCriteria)
+			Expect(newCriteriaPtr.getPrevCriteria()).To(BeIdenticalTo(&existingCriteria))
+		})
+		It("builds driver-ready query BSON", func() {
+			{
+				By("No ObjectIDs")
+				Expect(criteriaFind(nil).toBsonD()).To(Equal(bson.D{}))
+			}
+			{
+				By("One ObjectID")
+				id := NewObjectID()
+				Expect(criteriaFind(nil, id).toBsonD()).To(Equal(bson.D{{Key: "_id", Value: id}}))
+			}
+			{
+				By("Many ObjectIDs")
+				ids := []ObjectID{NewObjectID(), NewObjectID(), NewObjectID()}
+				Expect(criteriaFind(nil, ids...).toBsonD()).To(Equal(bson.D{{Key: "_id", Value: bson.M{"$in": ids}}}))
+			}
+		})
+		It("composes with Where", func() {
+			id := NewObjectID()
+			criteria := criteriaWhere(nil, nil, Q{"breed": "mutt"}).Find(id).(*criteriaStruct)
+			Expect(criteria.getFilterBsonD()).To(Equal(bson.D{
+				{Key: "breed", Value: "mutt"},
+				{Key: "_id", Value: id},
+			}))
+		})
+		It("collects the requested ids across the chain", func() {
+			id1, id2 := NewObjectID(), NewObjectID()
+			criteria := criteriaWhere(nil, nil).Find(id1, id2).Where(Q{"a": 1}).Find(id2).(*criteriaStruct)
+			Expect(criteria.getFindIDs()).To(Equal([]ObjectID{id1, id2}))
+		})
+		It("never excludes _id from the projection, so the ids can be verified", func() {
+			id := NewObjectID()
+			Expect(criteriaWhere(nil, nil).Without("_id", "name").Find(id).(*criteriaStruct).getProjectionBsonD()).To(Equal(bson.D{{Key: "name", Value: int32(0)}}))
+			Expect(criteriaWhere(nil, nil).Only("name").Without("_id").Find(id).(*criteriaStruct).getProjectionBsonD()).To(Equal(bson.D{{Key: "name", Value: int32(1)}}))
+		})
+	})
+})
@@ -0,0 +1,88 @@
+package mongoid
+
+import (
+	mongoidError "mongoid/errors"
+	"mongoid/log"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// First returns the first document of the collection, sorted by _id
+func (model *ModelType) First() (IDocumentBase, error) {
+	log.Debugf("%v.First()", model.GetModelName())
+	return criteriaWhere(model, nil).(*criteriaStruct).First()
+}
+
+// First returns the first document matching the criteria, according to the sort order of the criteria (or _id when there isn't one).
+// Documents with equal sort values are ordered by _id, so the same document is always returned.
+// Only the one document is requested from the database server. A ResultNotFound error is returned when nothing matches.
+func (criteria *criteriaStruct) First() (IDocumentBase, error) {
+	log.Debug("Criteria.First")
+	return criteria.firstOrLast("Criteria.First", false)
+}
+
+// Last returns the last document of the collection, sorted by _id
+func (model *ModelType) Last() (IDocumentBase, error) {
+	log.Debugf("%v.Last()", model.GetModelName())
+	return criteriaWhere(model, nil).(*criteriaStruct).Last()
+}
+
+// Last returns the last document matching the criteria, according to the sort order of the criteria (or _id when there isn't one).
+// Documents with equal sort values are ordered by _id, so the same document is always returned.
+// When possible, the sort order is reversed so that only the one document is requested from the database server.
+// When the criteria includes a Skip or Limit, or a sort that cannot be reversed (such as SortByTextScore), every document
+// within the selected window is read in order instead, and the last of them is returned.
+// A ResultNotFound error is returned when nothing matches.
+func (criteria *criteriaStruct) Last() (IDocumentBase, error) {
+	log.Debug("Criteria.Last")
+	return criteria.firstOrLast("Criteria.Last", true)
+}
+
+func (criteria *criteriaStruct) firstOrLast(methodName string, last bool) (IDocumentBase, error) {
+	model := criteria.getExecutionModel(methodName)
+	query, takeLast := criteria.firstOrLastCriteria(last)
+	res := query.execute(model)
+	count := res.Count()
+	if count == 0 {
+		return nil, mongoidError.ResultNotFound{}
+	}
+	if takeLast {
+		return res.at(count - 1), nil
+	}
+	return res.at(0), nil
+}
+
+// builds the criteria to execute for First (or Last), and whether the wanted document is the last of its results rather than the first
+func (criteria *criteriaStruct) firstOrLastCriteria(last bool) (query *criteriaStruct, takeLast bool) {
+	sortD := criteria.getSortBsonD()
+	if !bsonDHasKey(sortD, "_id") {
+		sortD = append(sortD, bson.E{Key: "_id", Value: int32(Ascending)}) // ties are broken by _id, for a deterministic document
+	}
+	if last {
+		_, hasSkip := criteria.getNumber(skipCriteria)
+		_, hasLimit := criteria.getNumber(limitCriteria)
+		reversedD, reversible := reverseSortBsonD(sortD)
+		if hasSkip || hasLimit || !reversible {
+			// reversing the sort would select a different window of documents, so read the window in order and take the last
+			return criteriaOrderBy(nil, criteria, sortD...).(*criteriaStruct), true
+		}
+		sortD = reversedD
+	}
+	// the new order link takes precedence over the direction of every field already sorted within the chain
+	return criteriaOrderBy(nil, criteria, sortD...).Limit(1).(*criteriaStruct), false
+}
+
+// returns a copy of the given sort document with the direction of every field reversed,
+// and false when any of the fields cannot be reversed (ie, {$meta: "textScore"})
+func reverseSortBsonD(sortD bson.D) (bson.D, bool) {
+	reversed := bson.D{}
+	for _, element := range sortD {
+		direction, ok := element.Value.(int32)
+		if !ok {
+			return nil, false
+		}
+		element.Value = -direction
+		reversed = append(reversed, element)
+	}
+	return reversed, true
+}
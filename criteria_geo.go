@@ -0,0 +1,114 @@
+package mongoid
+
+import (
+	"mongoid/log"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// GeoShape is an area to search within, for use with Within
+type GeoShape interface {
+	geoWithinBsonM() bson.M
+}
+
+var _ GeoShape = GeoPolygon{}
+var _ GeoShape = GeoCenterSphere{}
+var _ GeoShape = GeoBox{}
+
+// GeoCenterSphere is a circle on the surface of a sphere, for use as the shape of a Within query.
+// The radius is given in radians; divide a distance by the radius of the earth (ie, 6378.1 kilometers) to convert it.
+type GeoCenterSphere struct {
+	Center        GeoPoint
+	RadiusRadians float64
+}
+
+// GeoBox is a rectangle on a flat plane, given by its bottom left and top right corners, for use as the shape of a Within query
+type GeoBox struct {
+	BottomLeft GeoPoint
+	TopRight   GeoPoint
+}
+
+func (polygon GeoPolygon) geoWithinBsonM() bson.M {
+	return bson.M{"$geometry": polygon.toGeoJSON()}
+}
+
+func (centerSphere GeoCenterSphere) geoWithinBsonM() bson.M {
+	return bson.M{"$centerSphere": bson.A{centerSphere.Center.coordinates(), centerSphere.RadiusRadians}}
+}
+
+func (box GeoBox) geoWithinBsonM() bson.M {
+	return bson.M{"$box": bson.A{box.BottomLeft.coordinates(), box.TopRight.coordinates()}}
+}
+
+// Near adds criteria matching documents with a location in the given field, ordered from nearest to farthest from the given point.
+// The maxDistance is given in meters, and is ignored when zero. The field requires a 2dsphere index (see CreateGeoIndex).
+func (criteria *criteriaStruct) Near(field string, point GeoPoint, maxDistance float64) Criteria {
+	log.Debug("Criteria.Near ", field, point, maxDistance)
+	return criteria.geoNear("$near", field, point, maxDistance)
+}
+
+// NearSphere is the same as Near, but always calculates distances using spherical geometry
+func (criteria *criteriaStruct) NearSphere(field string, point GeoPoint, maxDistance float64) Criteria {
+	log.Debug("Criteria.NearSphere ", field, point, maxDistance)
+	return criteria.geoNear("$nearSphere", field, point, maxDistance)
+}
+
+func (criteria *criteriaStruct) geoNear(operator, field string, point GeoPoint, maxDistance float64) Criteria {
+	nearM := bson.M{"$geometry": point.toGeoJSON()}
+	if maxDistance > 0 {
+		nearM["$maxDistance"] = maxDistance
+	}
+	return criteria.geoWhere(field, bson.M{operator: nearM})
+}
+
+// Within adds criteria matching documents with a location in the given field that is entirely within the given shape
+func (criteria *criteriaStruct) Within(field string, shape GeoShape) Criteria {
+	log.Debug("Criteria.Within ", field, shape)
+	return criteria.geoWhere(field, bson.M{"$geoWithin": shape.geoWithinBsonM()})
+}
+
+// Intersects adds criteria matching documents with a location in the given field that intersects with the given geometry
+func (criteria *criteriaStruct) Intersects(field string, geometry GeoGeometry) Criteria {
+	log.Debug("Criteria.Intersects ", field, geometry)
+	return criteria.geoWhere(field, bson.M{"$geoIntersects": bson.M{"$geometry": geometry.toGeoJSON()}})
+}
+
+// adds a where link for the given geospatial operator, with the field name converted to its bson equivalent
+func (criteria *criteriaStruct) geoWhere(field string, operatorM bson.M) Criteria {
+	bsonPath := criteria.getSourceModel().GetBsonFieldPath(field)
+	return criteriaWhere(nil, criteria, Query{bsonPath: operatorM})
+}
+
+// Near starts a new Criteria matching documents near the given point (see Criteria.Near)
+func (model *ModelType) Near(field string, point GeoPoint, maxDistance float64) Criteria {
+	log.Debugf("%v.Near(%v)", model.GetModelName(), field)
+	return criteriaWhere(model, nil).Near(field, point, maxDistance)
+}
+
+// NearSphere starts a new Criteria matching documents near the given point (see Criteria.NearSphere)
+func (model *ModelType) NearSphere(field string, point GeoPoint, maxDistance float64) Criteria {
+	log.Debugf("%v.NearSphere(%v)", model.GetModelName(), field)
+	return criteriaWhere(model, nil).NearSphere(field, point, maxDistance)
+}
+
+// Within starts a new Criteria matching documents within the given shape (see Criteria.Within)
+func (model *ModelType) Within(field string, shape GeoShape) Criteria {
+	log.Debugf("%v.Within(%v)", model.GetModelName(), field)
+	return criteriaWhere(model, nil).Within(field, shape)
+}
+
+// Intersects starts a new Criteria matching documents that intersect the given geometry (see Criteria.Intersects)
+func (model *ModelType) Intersects(field string, geometry GeoGeometry) Criteria {
+	log.Debugf("%v.Intersects(%v)", model.GetModelName(), field)
+	return criteriaWhere(model, nil).Intersects(field, geometry)
+}
+
+// CreateGeoIndex creates a 2dsphere index of the given GeoJSON field on the collection of the ModelType, which is required by Near.
+// The field may be given as either the Go struct field name or the bson field name. The name of the index is returned.
+func (model *ModelType) CreateGeoIndex(field string) (string, error) {
+	log.Debugf("%v.CreateGeoIndex(%v)", model.GetModelName(), field)
+	keysD := bson.D{{Key: model.GetBsonFieldPath(field), Value: "2dsphere"}}
+	collection := model.getMongoCollectionHandle()
+	return collection.Indexes().CreateOne(model.GetClient().Context(), mongo.IndexModel{Keys: keysD})
+}
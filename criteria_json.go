@@ -0,0 +1,366 @@
+package mongoid
+
+import (
+	"fmt"
+	mongoidError "mongoid/errors"
+	"mongoid/log"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/tag"
+)
+
+// ToBsonD provides the complete query of the criteria chain, as it will be executed: the merged filter, along with the sort,
+// projection, skip, limit and query options when the criteria uses them (ie, {filter: {...}, sort: {...}, limit: 10, options: {comment: "..."}}).
+// Options given to the ModelType itself (such as SetReadPreference) are not included, since they apply to every criteria of the ModelType.
+func (criteria *criteriaStruct) ToBsonD() bson.D {
+	bsonD := criteria.getQueryBsonD()
+	if optionsD := criteria.getQueryOptions().toBsonD(); len(optionsD) > 0 {
+		bsonD = append(bsonD, bson.E{Key: "options", Value: optionsD})
+	}
+	return bsonD
+}
+
+// the filter, sort, projection, skip and limit of ToBsonD, which are also the same fields of a find command
+func (criteria *criteriaStruct) getQueryBsonD() bson.D {
+	bsonD := bson.D{{Key: "filter", Value: criteria.getFilterBsonD()}}
+	if sortD := criteria.getSortBsonD(); len(sortD) > 0 {
+		bsonD = append(bsonD, bson.E{Key: "sort", Value: sortD})
+	}
+	if projectionD := criteria.getProjectionBsonD(); len(projectionD) > 0 {
+		bsonD = append(bsonD, bson.E{Key: "projection", Value: projectionD})
+	}
+	if skip, found := criteria.getNumber(skipCriteria); found {
+		bsonD = append(bsonD, bson.E{Key: "skip", Value: skip})
+	}
+	if limit, found := criteria.getNumber(limitCriteria); found {
+		bsonD = append(bsonD, bson.E{Key: "limit", Value: limit})
+	}
+	return bsonD
+}
+
+// MarshalJSON implements json.Marshaler interface, providing the query of ToBsonD as canonical extended JSON.
+// The JSON may be stored or logged, and later turned back into a Criteria via ModelType.CriteriaFromJSON.
+func (criteria *criteriaStruct) MarshalJSON() ([]byte, error) {
+	return bson.MarshalExtJSON(criteria.ToBsonD(), true, false)
+}
+
+// CriteriaFromJSON rebuilds an executable Criteria from the extended JSON produced by Criteria.MarshalJSON.
+// Every field named by the filter, sort and projection must be the bson name of a field of the ModelType, otherwise a DocumentFieldNotFound error is returned.
+// The query options of the criteria (such as Collation, MaxTime and ReadPreference) are rebuilt as well.
+// The default scope of the ModelType is not applied, since a criteria made with the default scope already includes it.
+func (model *ModelType) CriteriaFromJSON(data []byte) (Criteria, error) {
+	log.Debugf("%v.CriteriaFromJSON()", model.GetModelName())
+	var criteriaD bson.D
+	if err := bson.UnmarshalExtJSON(data, false, &criteriaD); err != nil {
+		return nil, err
+	}
+
+	criteria := newCriteriaRoot(model)
+	for _, element := range criteriaD {
+		switch element.Key {
+		case "filter":
+			filterD, ok := element.Value.(bson.D)
+			if !ok {
+				return nil, invalidCriteriaJSON(element)
+			}
+			if err := model.validateFilterFields(filterD); err != nil {
+				return nil, err
+			}
+			criteria = &criteriaStruct{
+				criteriaType:   whereCriteria,
+				prevCriteria:   criteria,
+				thisQuery:      Query(filterD.Map()),
+				thisQueryBsonD: filterD,
+			}
+		case "sort", "projection":
+			fieldsD, ok := element.Value.(bson.D)
+			if !ok {
+				return nil, invalidCriteriaJSON(element)
+			}
+			for _, field := range fieldsD {
+				if field.Key != textScoreKey && !model.hasFieldPath(field.Key) {
+					return nil, mongoidError.DocumentFieldNotFound{FieldName: field.Key}
+				}
+			}
+			if element.Key == "sort" {
+				criteria = criteriaOrderBy(nil, criteria, fieldsD...).(*criteriaStruct)
+			} else {
+				criteria = criteriaProjection(nil, criteria, fieldsD).(*criteriaStruct)
+			}
+		case "skip", "limit":
+			number, ok := criteriaJSONNumber(element.Value)
+			if !ok {
+				return nil, invalidCriteriaJSON(element)
+			}
+			criteriaType := skipCriteria
+			if element.Key == "limit" {
+				criteriaType = limitCriteria
+			}
+			criteria = criteriaLimit(nil, criteria, criteriaType, number).(*criteriaStruct)
+		case "options":
+			optionsD, ok := element.Value.(bson.D)
+			if !ok {
+				return nil, invalidCriteriaJSON(element)
+			}
+			opts, err := queryOptionsFromBsonD(optionsD)
+			if err != nil {
+				return nil, err
+			}
+			criteria = criteriaOptions(criteria, opts).(*criteriaStruct)
+		default:
+			return nil, invalidCriteriaJSON(element)
+		}
+	}
+	return criteria, nil
+}
+
+// ensures every field named by the given filter is a field of the model, following the logical operators into their nested filters
+func (model *ModelType) validateFilterFields(filterD bson.D) error {
+	for _, element := range filterD {
+		switch element.Key {
+		case "$and", "$or", "$nor":
+			nestedA, _ := element.Value.(bson.A)
+			for _, nested := range nestedA {
+				if nestedD, ok := nested.(bson.D); ok {
+					if err := model.validateFilterFields(nestedD); err != nil {
+						return err
+					}
+				}
+			}
+			continue
+		}
+		if strings.HasPrefix(element.Key, "$") {
+			continue // other top-level operators ($text, $expr, etc) do not name fields in a way that can be checked
+		}
+		if !model.hasFieldPath(element.Key) {
+			return mongoidError.DocumentFieldNotFound{FieldName: element.Key}
+		}
+	}
+	return nil
+}
+
+// the options as a document of their command field names (ie, {maxTimeMS: 500, comment: "..."}), for ToBsonD
+func (opts queryOptions) toBsonD() bson.D {
+	optionsD := bson.D{}
+	if opts.collation != nil {
+		var collationD bson.D
+		if err := bson.Unmarshal(opts.collation.ToDocument(), &collationD); err != nil {
+			log.Panic(err) // the document was just built by the driver, so it should always unmarshal
+		}
+		optionsD = append(optionsD, bson.E{Key: "collation", Value: collationD})
+	}
+	if opts.hint != nil {
+		optionsD = append(optionsD, bson.E{Key: "hint", Value: opts.hint})
+	}
+	if opts.maxTime != nil {
+		optionsD = append(optionsD, bson.E{Key: "maxTimeMS", Value: int64(*opts.maxTime / time.Millisecond)})
+	}
+	if opts.batchSize != nil {
+		optionsD = append(optionsD, bson.E{Key: "batchSize", Value: *opts.batchSize})
+	}
+	if opts.comment != nil {
+		optionsD = append(optionsD, bson.E{Key: "comment", Value: *opts.comment})
+	}
+	if opts.noCursorTimeout != nil {
+		optionsD = append(optionsD, bson.E{Key: "noCursorTimeout", Value: *opts.noCursorTimeout})
+	}
+	if opts.allowDiskUse != nil {
+		optionsD = append(optionsD, bson.E{Key: "allowDiskUse", Value: *opts.allowDiskUse})
+	}
+	if opts.readPreference != nil {
+		readPrefD := bson.D{{Key: "mode", Value: opts.readPreference.Mode().String()}}
+		if tagSets := opts.readPreference.TagSets(); len(tagSets) > 0 {
+			tagSetsA := bson.A{}
+			for _, tagSet := range tagSets {
+				tagSetD := bson.D{}
+				for _, t := range tagSet {
+					tagSetD = append(tagSetD, bson.E{Key: t.Name, Value: t.Value})
+				}
+				tagSetsA = append(tagSetsA, tagSetD)
+			}
+			readPrefD = append(readPrefD, bson.E{Key: "tagSets", Value: tagSetsA})
+		}
+		if maxStaleness, set := opts.readPreference.MaxStaleness(); set {
+			readPrefD = append(readPrefD, bson.E{Key: "maxStalenessMS", Value: int64(maxStaleness / time.Millisecond)})
+		}
+		optionsD = append(optionsD, bson.E{Key: "readPreference", Value: readPrefD})
+	}
+	if opts.readConcern != nil {
+		optionsD = append(optionsD, bson.E{Key: "readConcern", Value: opts.readConcern.GetLevel()})
+	}
+	return optionsD
+}
+
+// rebuilds the options from the document given by queryOptions.toBsonD
+func queryOptionsFromBsonD(optionsD bson.D) (queryOptions, error) {
+	opts := queryOptions{}
+	for _, element := range optionsD {
+		ok := true
+		switch element.Key {
+		case "collation":
+			var collationD bson.D
+			if collationD, ok = element.Value.(bson.D); ok {
+				opts.collation, ok = collationFromBsonD(collationD)
+			}
+		case "hint":
+			opts.hint = element.Value
+		case "maxTimeMS":
+			var ms int64
+			if ms, ok = criteriaJSONNumber(element.Value); ok {
+				maxTime := time.Duration(ms) * time.Millisecond
+				opts.maxTime = &maxTime
+			}
+		case "batchSize":
+			var n int64
+			if n, ok = criteriaJSONNumber(element.Value); ok {
+				batchSize := int32(n)
+				opts.batchSize = &batchSize
+			}
+		case "comment":
+			var comment string
+			if comment, ok = element.Value.(string); ok {
+				opts.comment = &comment
+			}
+		case "noCursorTimeout":
+			var noCursorTimeout bool
+			if noCursorTimeout, ok = element.Value.(bool); ok {
+				opts.noCursorTimeout = &noCursorTimeout
+			}
+		case "allowDiskUse":
+			var allowDiskUse bool
+			if allowDiskUse, ok = element.Value.(bool); ok {
+				opts.allowDiskUse = &allowDiskUse
+			}
+		case "readPreference":
+			var readPrefD bson.D
+			if readPrefD, ok = element.Value.(bson.D); ok {
+				opts.readPreference, ok = readPreferenceFromBsonD(readPrefD)
+			}
+		case "readConcern":
+			var level string
+			if level, ok = element.Value.(string); ok {
+				opts.readConcern = readconcern.New(readconcern.Level(level))
+			}
+		default:
+			ok = false
+		}
+		if !ok {
+			return queryOptions{}, invalidCriteriaJSON(element)
+		}
+	}
+	return opts, nil
+}
+
+// rebuilds a Collation from the document given by Collation.ToDocument
+func collationFromBsonD(collationD bson.D) (*Collation, bool) {
+	collation := Collation{}
+	for _, element := range collationD {
+		ok := true
+		switch element.Key {
+		case "locale":
+			collation.Locale, ok = element.Value.(string)
+		case "caseLevel":
+			collation.CaseLevel, ok = element.Value.(bool)
+		case "caseFirst":
+			collation.CaseFirst, ok = element.Value.(string)
+		case "strength":
+			var strength int64
+			strength, ok = criteriaJSONNumber(element.Value)
+			collation.Strength = int(strength)
+		case "numericOrdering":
+			collation.NumericOrdering, ok = element.Value.(bool)
+		case "alternate":
+			collation.Alternate, ok = element.Value.(string)
+		case "maxVariable":
+			collation.MaxVariable, ok = element.Value.(string)
+		case "normalization":
+			collation.Normalization, ok = element.Value.(bool)
+		case "backwards":
+			collation.Backwards, ok = element.Value.(bool)
+		default:
+			ok = false
+		}
+		if !ok {
+			return nil, false
+		}
+	}
+	return &collation, true
+}
+
+// rebuilds a read preference from the document given by queryOptions.toBsonD
+func readPreferenceFromBsonD(readPrefD bson.D) (*readpref.ReadPref, bool) {
+	var mode ReadPreferenceMode // the zero value is not a valid mode, so the mode must be given
+	tagSets := []map[string]string{}
+	readPrefOpts := []readpref.Option{}
+	for _, element := range readPrefD {
+		switch element.Key {
+		case "mode":
+			modeName, ok := element.Value.(string)
+			if !ok {
+				return nil, false
+			}
+			var err error
+			if mode, err = readpref.ModeFromString(modeName); err != nil {
+				return nil, false
+			}
+		case "tagSets":
+			tagSetsA, ok := element.Value.(bson.A)
+			if !ok {
+				return nil, false
+			}
+			for _, tagSet := range tagSetsA {
+				tagSetD, ok := tagSet.(bson.D)
+				if !ok {
+					return nil, false
+				}
+				tagSetMap := map[string]string{}
+				for _, t := range tagSetD {
+					if tagSetMap[t.Key], ok = t.Value.(string); !ok {
+						return nil, false
+					}
+				}
+				tagSets = append(tagSets, tagSetMap)
+			}
+		case "maxStalenessMS":
+			ms, ok := criteriaJSONNumber(element.Value)
+			if !ok {
+				return nil, false
+			}
+			readPrefOpts = append(readPrefOpts, readpref.WithMaxStaleness(time.Duration(ms)*time.Millisecond))
+		default:
+			return nil, false
+		}
+	}
+	if len(tagSets) > 0 {
+		readPrefOpts = append(readPrefOpts, readpref.WithTagSets(tag.NewTagSetsFromMaps(tagSets)...))
+	}
+	readPreference, err := readpref.New(mode, readPrefOpts...)
+	if err != nil || mode == 0 {
+		return nil, false
+	}
+	return readPreference, true
+}
+
+// reads a skip or limit value, which may have been given as any numeric type
+func criteriaJSONNumber(value interface{}) (int64, bool) {
+	switch v := value.(type) {
+	case int64:
+		return v, true
+	case int32:
+		return int64(v), true
+	case float64:
+		return int64(v), float64(int64(v)) == v
+	}
+	return 0, false
+}
+
+func invalidCriteriaJSON(element bson.E) error {
+	return mongoidError.InvalidOperation{
+		MethodName: "ModelType.CriteriaFromJSON",
+		Reason:     fmt.Sprintf("invalid criteria entry '%v': %v", element.Key, element.Value),
+	}
+}
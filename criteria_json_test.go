@@ -0,0 +1,119 @@
+package mongoid
+
+import (
+	"encoding/json"
+	mongoidError "mongoid/errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Criteria extended JSON", func() {
+	type jsonTestDoc struct {
+		Base
+		ID   ObjectID `bson:"_id"`
+		Name string
+		Age  int
+	}
+	model := &ModelType{modelName: "jsonTestDoc", rootTypeRef: &jsonTestDoc{}}
+
+	It("provides the merged query via ToBsonD", func() {
+		criteria := model.Where(Q{"name": "bob"}).Where(Q{"age.$gte": 18}).Desc("age").Only("name").Skip(5).Limit(10)
+		Expect(criteria.ToBsonD()).To(Equal(bson.D{
+			{Key: "filter", Value: bson.D{{Key: "name", Value: "bob"}, {Key: "age", Value: bson.M{"$gte": 18}}}},
+			{Key: "sort", Value: bson.D{{Key: "age", Value: int32(-1)}}},
+			{Key: "projection", Value: bson.D{{Key: "name", Value: int32(1)}}},
+			{Key: "skip", Value: int64(5)},
+			{Key: "limit", Value: int64(10)},
+		}))
+		Expect(model.Where(nil).ToBsonD()).To(Equal(bson.D{{Key: "filter", Value: bson.D{}}}))
+	})
+
+	It("marshals to canonical extended JSON", func() {
+		id := NewObjectID()
+		data, err := json.Marshal(model.Where(Q{"_id": id}).Limit(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(data)).To(Equal(`{"filter":{"_id":{"$oid":"` + id.Hex() + `"}},"limit":{"$numberLong":"1"}}`))
+	})
+
+	It("round trips through CriteriaFromJSON", func() {
+		id := NewObjectID()
+		criteria := model.Where(Q{"_id": id}).Or(Q{"name": "bob"}, Q{"age.$lt": 3}).Asc("name").Without("age").Skip(2).Limit(4)
+		data, err := criteria.MarshalJSON()
+		Expect(err).ToNot(HaveOccurred())
+
+		rebuilt, err := model.CriteriaFromJSON(data)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rebuilt.(*criteriaStruct).getSourceModel()).To(BeIdenticalTo(model))
+		rebuiltData, err := rebuilt.MarshalJSON()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rebuiltData).To(MatchJSON(data))
+	})
+
+	It("round trips the query options", func() {
+		criteria := model.Where(Q{"name": "bob"}).
+			Collation(Collation{Locale: "en", Strength: 2}).
+			Hint("name_1").
+			MaxTime(1500*time.Millisecond).
+			BatchSize(50).
+			Comment("report").
+			NoCursorTimeout().
+			AllowDiskUse().
+			ReadPreference(ReadSecondaryPreferred, []map[string]string{{"region": "east"}}, 120*time.Second).
+			ReadConcern(ReadConcernMajority)
+		data, err := criteria.MarshalJSON()
+		Expect(err).ToNot(HaveOccurred())
+
+		rebuilt, err := model.CriteriaFromJSON(data)
+		Expect(err).ToNot(HaveOccurred())
+		opts := rebuilt.(*criteriaStruct).getQueryOptions()
+		Expect(*opts.collation).To(Equal(Collation{Locale: "en", Strength: 2}))
+		Expect(opts.hint).To(Equal("name_1"))
+		Expect(*opts.maxTime).To(Equal(1500 * time.Millisecond))
+		Expect(*opts.batchSize).To(Equal(int32(50)))
+		Expect(*opts.comment).To(Equal("report"))
+		Expect(*opts.noCursorTimeout).To(BeTrue())
+		Expect(*opts.allowDiskUse).To(BeTrue())
+		Expect(opts.readPreference.Mode()).To(Equal(ReadSecondaryPreferred))
+		Expect(opts.readPreference.TagSets()).To(HaveLen(1))
+		Expect(opts.readConcern.GetLevel()).To(Equal(ReadConcernMajority))
+		rebuiltData, err := rebuilt.MarshalJSON()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rebuiltData).To(MatchJSON(data))
+	})
+
+	It("accepts relaxed extended JSON", func() {
+		rebuilt, err := model.CriteriaFromJSON([]byte(`{"filter": {"age": {"$gt": 5}}, "limit": 3}`))
+		Expect(err).ToNot(HaveOccurred())
+		number, found := rebuilt.(*criteriaStruct).getNumber(limitCriteria)
+		Expect(found).To(BeTrue())
+		Expect(number).To(Equal(int64(3)))
+	})
+
+	It("rejects fields that are not part of the model", func() {
+		_, err := model.CriteriaFromJSON([]byte(`{"filter": {"nickname": "bob"}}`))
+		Expect(err).To(Equal(mongoidError.DocumentFieldNotFound{FieldName: "nickname"}))
+		_, err = model.CriteriaFromJSON([]byte(`{"filter": {"$or": [{"name": "bob"}, {"nickname": "bob"}]}}`))
+		Expect(err).To(Equal(mongoidError.DocumentFieldNotFound{FieldName: "nickname"}))
+		_, err = model.CriteriaFromJSON([]byte(`{"filter": {}, "sort": {"nickname": 1}}`))
+		Expect(err).To(Equal(mongoidError.DocumentFieldNotFound{FieldName: "nickname"}))
+		_, err = model.CriteriaFromJSON([]byte(`{"filter": {"Name": "bob"}}`))
+		Expect(err).To(Equal(mongoidError.DocumentFieldNotFound{FieldName: "Name"}), "Go field names never match within a filter")
+	})
+
+	It("rejects unknown entries and malformed JSON", func() {
+		_, err := model.CriteriaFromJSON([]byte(`{"filter": {}, "group": {}}`))
+		Expect(err).To(BeAssignableToTypeOf(mongoidError.InvalidOperation{}))
+		_, err = model.CriteriaFromJSON([]byte(`{"limit": "ten"}`))
+		Expect(err).To(BeAssignableToTypeOf(mongoidError.InvalidOperation{}))
+		_, err = model.CriteriaFromJSON([]byte(`{"options": {"maxTimeMS": "soon"}}`))
+		Expect(err).To(BeAssignableToTypeOf(mongoidError.InvalidOperation{}))
+		_, err = model.CriteriaFromJSON([]byte(`{"options": {"readPreference": {"tagSets": []}}}`))
+		Expect(err).To(BeAssignableToTypeOf(mongoidError.InvalidOperation{}))
+		_, err = model.CriteriaFromJSON([]byte(`not json`))
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,53 @@
+package mongoid
+
+import (
+	"mongoid/log"
+)
+
+// Limit restricts the maximum number of results returned
+func (model *ModelType) Limit(limit int64) Criteria {
+	log.Debug("ModelType.Limit ", limit)
+	return criteriaLimit(model, nil, limitCriteria, limit)
+}
+
+// Limit restricts the maximum number of results returned.
+// When called more than once, the most recent value is used.
+func (criteria *criteriaStruct) Limit(limit int64) Criteria {
+	log.Debug("Criteria.Limit ", limit)
+	return criteriaLimit(nil, criteria, limitCriteria, limit)
+}
+
+// Skip passes over the given number of results before returning any
+func (model *ModelType) Skip(skip int64) Criteria {
+	log.Debug("ModelType.Skip ", skip)
+	return criteriaLimit(model, nil, skipCriteria, skip)
+}
+
+// Skip passes over the given number of results before returning any.
+// When called more than once, the most recent value is used.
+func (criteria *criteriaStruct) Skip(skip int64) Criteria {
+	log.Debug("Criteria.Skip ", skip)
+	return criteriaLimit(nil, criteria, skipCriteria, skip)
+}
+
+func criteriaLimit(srcModel *ModelType, prevCriteria *criteriaStruct, criteriaType int, value int64) Criteria {
+	if prevCriteria == nil {
+		prevCriteria = criteriaWhere(srcModel, nil).(*criteriaStruct)
+	}
+	newCriteria := criteriaStruct{
+		criteriaType: criteriaType,
+		prevCriteria: prevCriteria,
+		thisNumber:   value,
+	}
+	return &newCriteria
+}
+
+// returns the most recent value given for the criteriaType (limitCriteria or skipCriteria) within the criteria chain, if any
+func (criteria *criteriaStruct) getNumber(criteriaType int) (value int64, found bool) {
+	for link := criteria; link != nil; link = link.prevCriteria {
+		if link.criteriaType == criteriaType {
+			return link.thisNumber, true
+		}
+	}
+	return 0, false
+}
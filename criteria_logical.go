@@ -0,0 +1,148 @@
+package mongoid
+
+import (
+	"mongoid/log"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Or creates a criteria that matches any of the given queries
+func (model *ModelType) Or(queries ...Query) Criteria {
+	log.Debug("ModelType.Or ", queries)
+	return criteriaLogical(model, nil, orCriteria, queries...)
+}
+
+// Or matches documents that match either the existing criteria or any of the given queries ($or).
+// Everything previously chained becomes the first alternative, so Where(a).Or(b) matches a OR b.
+// To require a match of the existing criteria AND one of the given queries, use AnyOf().
+func (criteria *criteriaStruct) Or(queries ...Query) Criteria {
+	log.Debug("Criteria.Or ", queries)
+	return criteriaLogical(nil, criteria, orCriteria, queries...)
+}
+
+// Nor creates a criteria that matches none of the given queries
+func (model *ModelType) Nor(queries ...Query) Criteria {
+	log.Debug("ModelType.Nor ", queries)
+	return criteriaLogical(model, nil, norCriteria, queries...)
+}
+
+// Nor adds criteria that must match none of the given queries ($nor)
+func (criteria *criteriaStruct) Nor(queries ...Query) Criteria {
+	log.Debug("Criteria.Nor ", queries)
+	return criteriaLogical(nil, criteria, norCriteria, queries...)
+}
+
+// AnyOf creates a criteria that matches any of the given queries
+func (model *ModelType) AnyOf(queries ...Query) Criteria {
+	log.Debug("ModelType.AnyOf ", queries)
+	return criteriaLogical(model, nil, anyOfCriteria, queries...)
+}
+
+// AnyOf adds criteria that must match at least one of the given queries ($or), in addition to the existing criteria
+func (criteria *criteriaStruct) AnyOf(queries ...Query) Criteria {
+	log.Debug("Criteria.AnyOf ", queries)
+	return criteriaLogical(nil, criteria, anyOfCriteria, queries...)
+}
+
+func criteriaLogical(srcModel *ModelType, prevCriteria *criteriaStruct, criteriaType int, queries ...Query) Criteria {
+	if prevCriteria == nil {
+		prevCriteria = criteriaWhere(srcModel, nil).(*criteriaStruct)
+	}
+	log.Traceln("New Criteria.Logical ", queries)
+	newCriteria := criteriaStruct{
+		criteriaType: criteriaType,
+		prevCriteria: prevCriteria,
+		thisQueries:  queries,
+	}
+	newCriteria.thisQueryBsonD = newCriteria.toBsonD()
+	return &newCriteria
+}
+
+// builds the bson.D for a logical operator link, where each of the link's queries is an entry of the operator array
+func criteriaLogicalToBsonD(operator string, logical *criteriaStruct) bson.D {
+	if logical.thisQueryBsonD != nil {
+		return logical.thisQueryBsonD
+	}
+	if len(logical.thisQueries) == 0 {
+		return bson.D{}
+	}
+	operatorA := bson.A{}
+	for _, query := range logical.thisQueries {
+		operatorA = append(operatorA, queryToBsonD(query))
+	}
+	return bson.D{{Key: operator, Value: operatorA}}
+}
+
+// Not adds criteria that must not match the field expressions of the given queries (see Query.Not)
+func (model *ModelType) Not(queries ...Query) Criteria {
+	log.Debug("ModelType.Not ", queries)
+	return criteriaWhere(model, nil, notQueries(queries)...)
+}
+
+// Not adds criteria that must not match the field expressions of the given queries (see Query.Not)
+func (criteria *criteriaStruct) Not(queries ...Query) Criteria {
+	log.Debug("Criteria.Not ", queries)
+	return criteriaWhere(nil, criteria, notQueries(queries)...)
+}
+
+func notQueries(queries []Query) []Query {
+	notQueries := make([]Query, 0, len(queries))
+	for _, query := range queries {
+		notQueries = append(notQueries, query.Not())
+	}
+	return notQueries
+}
+
+// Not returns a negated copy of the query's field expressions, for use within Where() or any of the logical criteria.
+// Operator expressions are wrapped with $not (ie, Q{"age.$gt": 5}.Not() matches documents where age is not greater than 5),
+// regular expressions are wrapped with $not, and other plain values (including embedded documents) become $ne.
+// Top-level operators (ie, $and, $or, $nor, $expr, $where) are negated with an enclosing $nor.
+func (query Query) Not() Query {
+	notQuery := Query{}
+	norA := bson.A{}
+	for _, element := range queryToBsonD(query) {
+		if strings.HasPrefix(element.Key, "$") {
+			norA = append(norA, bson.D{element})
+			continue
+		}
+		if _, isRegex := element.Value.(primitive.Regex); isRegex || isOperatorDocument(element.Value) {
+			notQuery[element.Key] = bson.M{"$not": element.Value}
+		} else {
+			notQuery[element.Key] = bson.M{"$ne": element.Value}
+		}
+	}
+	if len(norA) > 0 {
+		notQuery["$nor"] = norA
+	}
+	return notQuery
+}
+
+// returns true if the given value is a document of query operators (ie, {$gt: 5}), rather than an embedded document to match by equality
+func isOperatorDocument(value interface{}) bool {
+	keys := []string{}
+	switch v := value.(type) {
+	case bson.M:
+		for key := range v {
+			keys = append(keys, key)
+		}
+	case Query:
+		for key := range v {
+			keys = append(keys, key)
+		}
+	case bson.D:
+		for _, element := range v {
+			keys = append(keys, element.Key)
+		}
+	}
+	if len(keys) == 0 {
+		return false
+	}
+	for _, key := range keys {
+		if !strings.HasPrefix(key, "$") {
+			return false
+		}
+	}
+	return true
+}
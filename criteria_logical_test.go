@@ -0,0 +1,123 @@
+package mongoid
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Criteria Logical Operators", func() {
+	filterOf := func(criteria Criteria) bson.D {
+		return criteria.(*criteriaStruct).getFilterBsonD()
+	}
+
+	Describe("queryToBsonD", func() {
+		It("sorts keys for a stable output", func() {
+			Expect(queryToBsonD(Q{"b": 2, "a": 1, "c": 3})).To(Equal(bson.D{
+				{Key: "a", Value: 1},
+				{Key: "b", Value: 2},
+				{Key: "c", Value: 3},
+			}))
+		})
+		It("combines multiple operators on the same field", func() {
+			Expect(queryToBsonD(Q{"age.$gt": 1, "age.$lt": 9})).To(Equal(bson.D{
+				{Key: "age", Value: bson.M{"$gt": 1, "$lt": 9}},
+			}))
+		})
+		It("normalizes queries nested within logical operators", func() {
+			Expect(queryToBsonD(Q{"$or": []Q{{"age.$gt": 1}, {"name": "bob"}}})).To(Equal(bson.D{
+				{Key: "$or", Value: bson.A{
+					bson.D{{Key: "age", Value: bson.M{"$gt": 1}}},
+					bson.D{{Key: "name", Value: "bob"}},
+				}},
+			}))
+		})
+	})
+
+	Describe("Or", func() {
+		It("matches any of the given queries", func() {
+			Expect(filterOf(criteriaLogical(nil, nil, orCriteria, Q{"a": 1}, Q{"b": 2}))).To(Equal(bson.D{
+				{Key: "$or", Value: bson.A{bson.D{{Key: "a", Value: 1}}, bson.D{{Key: "b", Value: 2}}}},
+			}))
+		})
+		It("makes the previous criteria one of the alternatives", func() {
+			criteria := criteriaWhere(nil, nil, Q{"a": 1}).Where(Q{"b": 2}).Or(Q{"c": 3})
+			Expect(filterOf(criteria)).To(Equal(bson.D{
+				{Key: "$or", Value: bson.A{
+					bson.D{{Key: "a", Value: 1}, {Key: "b", Value: 2}},
+					bson.D{{Key: "c", Value: 3}},
+				}},
+			}))
+		})
+		It("is still restricted by criteria chained after it", func() {
+			criteria := criteriaWhere(nil, nil, Q{"a": 1}).Or(Q{"c": 3}).Where(Q{"d": 4})
+			Expect(filterOf(criteria)).To(Equal(bson.D{
+				{Key: "$or", Value: bson.A{bson.D{{Key: "a", Value: 1}}, bson.D{{Key: "c", Value: 3}}}},
+				{Key: "d", Value: 4},
+			}))
+		})
+		It("ignores an empty Or", func() {
+			Expect(filterOf(criteriaWhere(nil, nil, Q{"a": 1}).Or())).To(Equal(bson.D{{Key: "a", Value: 1}}))
+		})
+	})
+
+	Describe("AnyOf", func() {
+		It("requires the previous criteria and any of the given queries", func() {
+			criteria := criteriaWhere(nil, nil, Q{"a": 1}).AnyOf(Q{"b": 2}, Q{"c": 3})
+			Expect(filterOf(criteria)).To(Equal(bson.D{
+				{Key: "a", Value: 1},
+				{Key: "$or", Value: bson.A{bson.D{{Key: "b", Value: 2}}, bson.D{{Key: "c", Value: 3}}}},
+			}))
+		})
+		It("combines with another AnyOf via $and", func() {
+			criteria := criteriaWhere(nil, nil).AnyOf(Q{"a": 1}, Q{"b": 2}).AnyOf(Q{"c": 3}, Q{"d": 4})
+			filter := filterOf(criteria)
+			Expect(filter).To(HaveLen(1))
+			Expect(filter[0].Key).To(Equal("$and"))
+		})
+	})
+
+	Describe("Nor", func() {
+		It("requires none of the given queries", func() {
+			criteria := criteriaWhere(nil, nil, Q{"a": 1}).Nor(Q{"b": 2})
+			Expect(filterOf(criteria)).To(Equal(bson.D{
+				{Key: "a", Value: 1},
+				{Key: "$nor", Value: bson.A{bson.D{{Key: "b", Value: 2}}}},
+			}))
+		})
+	})
+
+	Describe("Query.Not", func() {
+		It("wraps operator expressions with $not", func() {
+			Expect(Q{"age.$gt": 5}.Not()).To(Equal(Q{"age": bson.M{"$not": bson.M{"$gt": 5}}}))
+		})
+		It("converts plain values into $ne", func() {
+			Expect(Q{"name": "bob"}.Not()).To(Equal(Q{"name": bson.M{"$ne": "bob"}}))
+		})
+		It("converts embedded document values into $ne", func() {
+			Expect(Q{"addr": bson.M{"city": "x"}}.Not()).To(Equal(Q{"addr": bson.M{"$ne": bson.M{"city": "x"}}}))
+			Expect(Q{"addr": bson.D{{Key: "city", Value: "x"}}}.Not()).To(Equal(Q{"addr": bson.M{"$ne": bson.D{{Key: "city", Value: "x"}}}}))
+		})
+		It("negates top-level operators with $nor", func() {
+			expr := bson.M{"$gt": bson.A{"$spent", "$budget"}}
+			Expect(Q{"$expr": expr}.Not()).To(Equal(Q{"$nor": bson.A{bson.D{{Key: "$expr", Value: expr}}}}))
+			Expect(Q{"$where": "this.a > 1"}.Not()).To(Equal(Q{"$nor": bson.A{bson.D{{Key: "$where", Value: "this.a > 1"}}}}))
+		})
+		It("wraps operator documents given as values with $not", func() {
+			Expect(Q{"age": bson.M{"$gt": 5}}.Not()).To(Equal(Q{"age": bson.M{"$not": bson.M{"$gt": 5}}}))
+		})
+		It("negates logical operators with $nor", func() {
+			notQuery := Q{"$or": []Q{{"a": 1}}}.Not()
+			Expect(notQuery).To(HaveKey("$nor"))
+		})
+		It("can be used within a Where", func() {
+			criteria := criteriaWhere(nil, nil, Q{"age.$lt": 5}.Not())
+			Expect(filterOf(criteria)).To(Equal(bson.D{{Key: "age", Value: bson.M{"$not": bson.M{"$lt": 5}}}}))
+		})
+		It("can be used as a Criteria link", func() {
+			criteria := criteriaWhere(nil, nil, Q{"a": 1}).Not(Q{"b": 2})
+			Expect(filterOf(criteria)).To(Equal(bson.D{{Key: "a", Value: 1}, {Key: "b", Value: bson.M{"$ne": 2}}}))
+		})
+	})
+})
@@ -0,0 +1,244 @@
+package mongoid
+
+import (
+	"mongoid/log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+// Collation is the language-specific rules for string comparison, as implemented by mongo-go-driver (see Criteria.Collation)
+type Collation = options.Collation
+
+// the driver options given to a criteria chain, where nil fields were not given
+type queryOptions struct {
+	collation       *Collation
+	hint            interface{}
+	maxTime         *time.Duration
+	batchSize       *int32
+	comment         *string
+	noCursorTimeout *bool
+	allowDiskUse    *bool
+	readPreference  *readpref.ReadPref
+	readConcern     *readconcern.ReadConcern
+}
+
+// Collation uses the given collation for string comparisons within the filter and sort of the criteria (ie, Collation{Locale: "en", Strength: 2}
+// for case insensitive matching). Indexes are only used by the query when they have the same collation.
+func (criteria *criteriaStruct) Collation(collation Collation) Criteria {
+	log.Debug("Criteria.Collation ", collation)
+	return criteriaOptions(criteria, queryOptions{collation: &collation})
+}
+
+// Hint forces the query to use the given index, given as either the index name or the index keys (ie, bson.D{{Key: "name", Value: 1}})
+func (criteria *criteriaStruct) Hint(index interface{}) Criteria {
+	log.Debug("Criteria.Hint ", index)
+	return criteriaOptions(criteria, queryOptions{hint: index})
+}
+
+// MaxTime limits how long the database server may spend running the query before aborting it
+func (criteria *criteriaStruct) MaxTime(d time.Duration) Criteria {
+	log.Debug("Criteria.MaxTime ", d)
+	return criteriaOptions(criteria, queryOptions{maxTime: &d})
+}
+
+// BatchSize sets the number of documents returned by each round trip to the database server while reading results
+func (criteria *criteriaStruct) BatchSize(n int32) Criteria {
+	log.Debug("Criteria.BatchSize ", n)
+	return criteriaOptions(criteria, queryOptions{batchSize: &n})
+}
+
+// Comment attaches the given comment to the query, which is included in the database server logs and profiler output
+func (criteria *criteriaStruct) Comment(comment string) Criteria {
+	log.Debug("Criteria.Comment ", comment)
+	return criteriaOptions(criteria, queryOptions{comment: &comment})
+}
+
+// NoCursorTimeout prevents the database server from closing the cursor of the query after a period of inactivity
+func (criteria *criteriaStruct) NoCursorTimeout() Criteria {
+	log.Debug("Criteria.NoCursorTimeout")
+	noCursorTimeout := true
+	return criteriaOptions(criteria, queryOptions{noCursorTimeout: &noCursorTimeout})
+}
+
+// AllowDiskUse permits the database server to write temporary files while sorting or aggregating, for results larger than its memory limit
+func (criteria *criteriaStruct) AllowDiskUse() Criteria {
+	log.Debug("Criteria.AllowDiskUse")
+	allowDiskUse := true
+	return criteriaOptions(criteria, queryOptions{allowDiskUse: &allowDiskUse})
+}
+
+func criteriaOptions(prevCriteria *criteriaStruct, opts queryOptions) Criteria {
+	newCriteria := criteriaStruct{
+		criteriaType: optionsCriteria,
+		prevCriteria: prevCriteria,
+		thisOptions:  &opts,
+	}
+	return &newCriteria
+}
+
+// returns the options given within the criteria chain; when an option is given more than once, the most recent value is used
+func (criteria *criteriaStruct) getQueryOptions() queryOptions {
+	merged := queryOptions{}
+	for _, link := range criteria.getChain() {
+		if link.criteriaType != optionsCriteria {
+			continue
+		}
+		opts := link.thisOptions
+		if opts.collation != nil {
+			merged.collation = opts.collation
+		}
+		if opts.hint != nil {
+			merged.hint = opts.hint
+		}
+		if opts.maxTime != nil {
+			merged.maxTime = opts.maxTime
+		}
+		if opts.batchSize != nil {
+			merged.batchSize = opts.batchSize
+		}
+		if opts.comment != nil {
+			merged.comment = opts.comment
+		}
+		if opts.noCursorTimeout != nil {
+			merged.noCursorTimeout = opts.noCursorTimeout
+		}
+		if opts.allowDiskUse != nil {
+			merged.allowDiskUse = opts.allowDiskUse
+		}
+		if opts.readPreference != nil {
+			merged.readPreference = opts.readPreference
+		}
+		if opts.readConcern != nil {
+			merged.readConcern = opts.readConcern
+		}
+	}
+	return merged
+}
+
+func (opts queryOptions) applyToFind(findOpts *options.FindOptions) {
+	if opts.collation != nil {
+		findOpts.SetCollation(opts.collation)
+	}
+	if opts.hint != nil {
+		findOpts.SetHint(opts.hint)
+	}
+	if opts.maxTime != nil {
+		findOpts.SetMaxTime(*opts.maxTime)
+	}
+	if opts.batchSize != nil {
+		findOpts.SetBatchSize(*opts.batchSize)
+	}
+	if opts.comment != nil {
+		findOpts.SetComment(*opts.comment)
+	}
+	if opts.noCursorTimeout != nil {
+		findOpts.SetNoCursorTimeout(*opts.noCursorTimeout)
+	}
+	if opts.allowDiskUse != nil {
+		findOpts.SetAllowDiskUse(*opts.allowDiskUse)
+	}
+}
+
+// a single document is read, so there is no cursor to keep alive nor a sort large enough to need the disk
+func (opts queryOptions) applyToFindOne(findOpts *options.FindOneOptions) {
+	if opts.collation != nil {
+		findOpts.SetCollation(opts.collation)
+	}
+	if opts.hint != nil {
+		findOpts.SetHint(opts.hint)
+	}
+	if opts.maxTime != nil {
+		findOpts.SetMaxTime(*opts.maxTime)
+	}
+	if opts.comment != nil {
+		findOpts.SetComment(*opts.comment)
+	}
+}
+
+// counting does not support comments with this driver version, and returns no cursor
+func (opts queryOptions) applyToCount(countOpts *options.CountOptions) {
+	if opts.collation != nil {
+		countOpts.SetCollation(opts.collation)
+	}
+	if opts.hint != nil {
+		countOpts.SetHint(opts.hint)
+	}
+	if opts.maxTime != nil {
+		countOpts.SetMaxTime(*opts.maxTime)
+	}
+}
+
+func (opts queryOptions) applyToDistinct(distinctOpts *options.DistinctOptions) {
+	if opts.collation != nil {
+		distinctOpts.SetCollation(opts.collation)
+	}
+	if opts.maxTime != nil {
+		distinctOpts.SetMaxTime(*opts.maxTime)
+	}
+}
+
+// aggregation cursors cannot be kept alive with noCursorTimeout
+func (opts queryOptions) applyToAggregate(aggregateOpts *options.AggregateOptions) {
+	if opts.collation != nil {
+		aggregateOpts.SetCollation(opts.collation)
+	}
+	if opts.hint != nil {
+		aggregateOpts.SetHint(opts.hint)
+	}
+	if opts.maxTime != nil {
+		aggregateOpts.SetMaxTime(*opts.maxTime)
+	}
+	if opts.batchSize != nil {
+		aggregateOpts.SetBatchSize(*opts.batchSize)
+	}
+	if opts.comment != nil {
+		aggregateOpts.SetComment(*opts.comment)
+	}
+	if opts.allowDiskUse != nil {
+		aggregateOpts.SetAllowDiskUse(*opts.allowDiskUse)
+	}
+}
+
+// updates and deletes only select documents, so only the options that change how they are matched apply
+func (opts queryOptions) applyToUpdate(updateOpts *options.UpdateOptions) {
+	if opts.collation != nil {
+		updateOpts.SetCollation(opts.collation)
+	}
+	if opts.hint != nil {
+		updateOpts.SetHint(opts.hint)
+	}
+}
+
+func (opts queryOptions) applyToDelete(deleteOpts *options.DeleteOptions) {
+	if opts.collation != nil {
+		deleteOpts.SetCollation(opts.collation)
+	}
+	if opts.hint != nil {
+		deleteOpts.SetHint(opts.hint)
+	}
+}
+
+// the options that change how the query is planned, as fields of a find command (for Explain)
+func (opts queryOptions) findCommandBsonD() bson.D {
+	commandD := bson.D{}
+	if opts.collation != nil {
+		commandD = append(commandD, bson.E{Key: "collation", Value: opts.collation.ToDocument()})
+	}
+	if opts.hint != nil {
+		commandD = append(commandD, bson.E{Key: "hint", Value: opts.hint})
+	}
+	if opts.maxTime != nil {
+		commandD = append(commandD, bson.E{Key: "maxTimeMS", Value: int64(*opts.maxTime / time.Millisecond)})
+	}
+	if opts.comment != nil {
+		commandD = append(commandD, bson.E{Key: "comment", Value: *opts.comment})
+	}
+	if opts.allowDiskUse != nil {
+		commandD = append(commandD, bson.E{Key: "allowDiskUse", Value: *opts.allowDiskUse})
+	}
+	return commandD
+}
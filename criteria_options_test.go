@@ -0,0 +1,69 @@
+package mongoid
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Criteria query options", func() {
+	collation := Collation{Locale: "en", Strength: 2}
+
+	It("flow into the find options", func() {
+		criteria := newCriteriaRoot(nil).Collation(collation).Hint("name_1").MaxTime(time.Second).
+			BatchSize(50).Comment("report").NoCursorTimeout().AllowDiskUse().(*criteriaStruct)
+		findOpts := criteria.getFindOptions()
+		Expect(*findOpts.Collation).To(Equal(collation))
+		Expect(findOpts.Hint).To(Equal("name_1"))
+		Expect(*findOpts.MaxTime).To(Equal(time.Second))
+		Expect(*findOpts.BatchSize).To(Equal(int32(50)))
+		Expect(*findOpts.Comment).To(Equal("report"))
+		Expect(*findOpts.NoCursorTimeout).To(BeTrue())
+		Expect(*findOpts.AllowDiskUse).To(BeTrue())
+	})
+
+	It("use the most recent value when given more than once", func() {
+		criteria := newCriteriaRoot(nil).Comment("first").Where(Q{"name": "bob"}).Comment("second").(*criteriaStruct)
+		Expect(*criteria.getFindOptions().Comment).To(Equal("second"))
+		Expect(criteria.getFilterBsonD()).To(Equal(bson.D{{Key: "name", Value: "bob"}}))
+	})
+
+	It("leave the driver defaults alone when not given", func() {
+		findOpts := newCriteriaRoot(nil).Limit(1).(*criteriaStruct).getFindOptions()
+		Expect(findOpts.Collation).To(BeNil())
+		Expect(findOpts.Hint).To(BeNil())
+		Expect(findOpts.MaxTime).To(BeNil())
+	})
+
+	It("flow into the count and aggregate options", func() {
+		opts := newCriteriaRoot(nil).Collation(collation).Hint("name_1").AllowDiskUse().(*criteriaStruct).getQueryOptions()
+		countOpts := options.Count()
+		opts.applyToCount(countOpts)
+		Expect(*countOpts.Collation).To(Equal(collation))
+		Expect(countOpts.Hint).To(Equal("name_1"))
+		aggregateOpts := options.Aggregate()
+		opts.applyToAggregate(aggregateOpts)
+		Expect(*aggregateOpts.Collation).To(Equal(collation))
+		Expect(*aggregateOpts.AllowDiskUse).To(BeTrue())
+	})
+
+	It("are carried into aggregations started from the criteria", func() {
+		model := &ModelType{modelName: "optionsTestModel"}
+		agg := newCriteriaRoot(model).MaxTime(time.Second).Aggregate().Limit(5).(*aggregationStruct)
+		Expect(*agg.getAggregateOptions().MaxTime).To(Equal(time.Second))
+	})
+
+	It("are included in the explained find command", func() {
+		criteria := newCriteriaRoot(nil).Hint("name_1").MaxTime(2 * time.Second).(*criteriaStruct)
+		Expect(criteria.getExplainFindBsonD("people")).To(Equal(bson.D{
+			{Key: "find", Value: "people"},
+			{Key: "filter", Value: bson.D{}},
+			{Key: "hint", Value: "name_1"},
+			{Key: "maxTimeMS", Value: int64(2000)},
+		}))
+	})
+})
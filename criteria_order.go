@@ -0,0 +1,108 @@
+package mongoid
+
+import (
+	"mongoid/log"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// SortDirection is the order in which query results are sorted for a given field
+type SortDirection int
+
+const (
+	// Ascending sorts results from the lowest value to the highest value
+	Ascending SortDirection = 1
+	// Descending sorts results from the highest value to the lowest value
+	Descending SortDirection = -1
+)
+
+// OrderBy sorts the results by the given field and direction.
+// Fields may be named by either their struct field name or their bson field name.
+// When called more than once, results are sorted by each field in the order they were given.
+func (model *ModelType) OrderBy(field string, direction SortDirection) Criteria {
+	log.Debug("ModelType.OrderBy ", field, direction)
+	return criteriaOrderBy(model, nil, bson.E{Key: field, Value: direction})
+}
+
+// OrderBy sorts the results by the given field and direction.
+// Fields may be named by either their struct field name or their bson field name.
+// When called more than once, results are sorted by each field in the order they were given.
+func (criteria *criteriaStruct) OrderBy(field string, direction SortDirection) Criteria {
+	log.Debug("Criteria.OrderBy ", field, direction)
+	return criteriaOrderBy(nil, criteria, bson.E{Key: field, Value: direction})
+}
+
+// Asc sorts the results by the given fields in ascending order
+func (model *ModelType) Asc(fields ...string) Criteria {
+	log.Debug("ModelType.Asc ", fields)
+	return criteriaOrderBy(model, nil, sortFieldsToBsonD(Ascending, fields...)...)
+}
+
+// Asc sorts the results by the given fields in ascending order
+func (criteria *criteriaStruct) Asc(fields ...string) Criteria {
+	log.Debug("Criteria.Asc ", fields)
+	return criteriaOrderBy(nil, criteria, sortFieldsToBsonD(Ascending, fields...)...)
+}
+
+// Desc sorts the results by the given fields in descending order
+func (model *ModelType) Desc(fields ...string) Criteria {
+	log.Debug("ModelType.Desc ", fields)
+	return criteriaOrderBy(model, nil, sortFieldsToBsonD(Descending, fields...)...)
+}
+
+// Desc sorts the results by the given fields in descending order
+func (criteria *criteriaStruct) Desc(fields ...string) Criteria {
+	log.Debug("Criteria.Desc ", fields)
+	return criteriaOrderBy(nil, criteria, sortFieldsToBsonD(Descending, fields...)...)
+}
+
+func sortFieldsToBsonD(direction SortDirection, fields ...string) bson.D {
+	sortD := bson.D{}
+	for _, field := range fields {
+		sortD = append(sortD, bson.E{Key: field, Value: direction})
+	}
+	return sortD
+}
+
+func criteriaOrderBy(srcModel *ModelType, prevCriteria *criteriaStruct, order ...bson.E) Criteria {
+	if prevCriteria == nil {
+		prevCriteria = criteriaWhere(srcModel, nil).(*criteriaStruct)
+	}
+	log.Traceln("New Criteria.OrderBy ", order)
+	newCriteria := criteriaStruct{
+		criteriaType: orderCriteria,
+		prevCriteria: prevCriteria,
+		thisOrder:    order,
+	}
+	return &newCriteria
+}
+
+// builds the driver-ready sort document for the criteria chain, with field names converted to their bson equivalents
+// a field that is sorted more than once keeps its original position, but takes the most recent direction
+func (criteria *criteriaStruct) getSortBsonD() bson.D {
+	model := criteria.getSourceModel()
+	sortD := bson.D{}
+	for _, link := range criteria.getChain() {
+		if link.criteriaType != orderCriteria {
+			continue
+		}
+		for _, element := range link.thisOrder {
+			key := model.GetBsonFieldPath(element.Key)
+			value := element.Value
+			if direction, ok := value.(SortDirection); ok {
+				value = int32(direction)
+			}
+			replaced := false
+			for i := range sortD {
+				if sortD[i].Key == key {
+					sortD[i].Value = value
+					replaced = true
+				}
+			}
+			if !replaced {
+				sortD = append(sortD, bson.E{Key: key, Value: value})
+			}
+		}
+	}
+	return sortD
+}
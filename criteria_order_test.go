@@ -0,0 +1,95 @@
+package mongoid
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Criteria Ordering", func() {
+	type orderTestDoc struct {
+		Base
+		FirstName string
+		Age       int `bson:"years"`
+	}
+	model := &ModelType{rootTypeRef: &orderTestDoc{}}
+
+	It("builds no sort without ordering", func() {
+		findOpts := criteriaWhere(model, nil).(*criteriaStruct).getFindOptions()
+		Expect(findOpts.Sort).To(BeNil())
+		Expect(findOpts.Limit).To(BeNil())
+		Expect(findOpts.Skip).To(BeNil())
+	})
+	It("sorts by bson field names in the order given", func() {
+		criteria := model.Asc("FirstName").Desc("Age").(*criteriaStruct)
+		Expect(criteria.getFindOptions().Sort).To(Equal(bson.D{
+			{Key: "first_name", Value: int32(1)},
+			{Key: "years", Value: int32(-1)},
+		}))
+	})
+	It("keeps the most recent direction for a repeated field", func() {
+		criteria := model.OrderBy("FirstName", Ascending).OrderBy("_id", Ascending).OrderBy("first_name", Descending).(*criteriaStruct)
+		Expect(criteria.getSortBsonD()).To(Equal(bson.D{
+			{Key: "first_name", Value: int32(-1)},
+			{Key: "_id", Value: int32(1)},
+		}))
+	})
+	It("keeps the filter of the chain", func() {
+		criteria := model.Where(Q{"first_name": "bob"}).Asc("Age").(*criteriaStruct)
+		Expect(criteria.getFilterBsonD()).To(Equal(bson.D{{Key: "first_name", Value: "bob"}}))
+	})
+	It("uses the most recent Limit and Skip", func() {
+		criteria := model.Limit(5).Skip(10).Where(Q{}).Limit(20).(*criteriaStruct)
+		findOpts := criteria.getFindOptions()
+		Expect(*findOpts.Limit).To(Equal(int64(20)))
+		Expect(*findOpts.Skip).To(Equal(int64(10)))
+	})
+
+	Describe("reverseSortBsonD", func() {
+		It("reverses the direction of every field", func() {
+			sortD := bson.D{{Key: "a", Value: int32(1)}, {Key: "b", Value: int32(-1)}}
+			reversed, ok := reverseSortBsonD(sortD)
+			Expect(ok).To(BeTrue())
+			Expect(reversed).To(Equal(bson.D{{Key: "a", Value: int32(-1)}, {Key: "b", Value: int32(1)}}))
+			Expect(sortD[0].Value).To(Equal(int32(1)), "leaves the original unaltered")
+		})
+		It("cannot reverse a text score sort", func() {
+			_, ok := reverseSortBsonD(bson.D{{Key: "a", Value: int32(1)}, {Key: textScoreKey, Value: textScoreMetaBsonD()}})
+			Expect(ok).To(BeFalse())
+		})
+		It("takes precedence within the chain when appended as an order", func() {
+			criteria := criteriaWhere(nil, nil).Asc("a").Desc("b").(*criteriaStruct)
+			reversedD, _ := reverseSortBsonD(criteria.getSortBsonD())
+			reversed := criteriaOrderBy(nil, criteria, reversedD...).(*criteriaStruct)
+			Expect(reversed.getSortBsonD()).To(Equal(bson.D{{Key: "a", Value: int32(-1)}, {Key: "b", Value: int32(1)}}))
+		})
+	})
+
+	Describe("firstOrLastCriteria", func() {
+		It("requests one document, breaking ties by _id", func() {
+			query, takeLast := criteriaWhere(nil, nil).Desc("a").(*criteriaStruct).firstOrLastCriteria(false)
+			Expect(takeLast).To(BeFalse())
+			Expect(query.getSortBsonD()).To(Equal(bson.D{{Key: "a", Value: int32(-1)}, {Key: "_id", Value: int32(1)}}))
+			Expect(*query.getFindOptions().Limit).To(Equal(int64(1)))
+		})
+		It("reverses the sort for Last", func() {
+			query, takeLast := criteriaWhere(nil, nil).Desc("a").(*criteriaStruct).firstOrLastCriteria(true)
+			Expect(takeLast).To(BeFalse())
+			Expect(query.getSortBsonD()).To(Equal(bson.D{{Key: "a", Value: int32(1)}, {Key: "_id", Value: int32(-1)}}))
+			Expect(*query.getFindOptions().Limit).To(Equal(int64(1)))
+		})
+		It("reads the window in order for Last with Skip or Limit", func() {
+			query, takeLast := criteriaWhere(nil, nil).Desc("a").Skip(5).Limit(10).(*criteriaStruct).firstOrLastCriteria(true)
+			Expect(takeLast).To(BeTrue())
+			Expect(query.getSortBsonD()).To(Equal(bson.D{{Key: "a", Value: int32(-1)}, {Key: "_id", Value: int32(1)}}))
+			Expect(*query.getFindOptions().Limit).To(Equal(int64(10)))
+			Expect(*query.getFindOptions().Skip).To(Equal(int64(5)))
+		})
+		It("reads in order for Last with a text score sort", func() {
+			query, takeLast := criteriaWhere(nil, nil).SortByTextScore().(*criteriaStruct).firstOrLastCriteria(true)
+			Expect(takeLast).To(BeTrue())
+			Expect(query.getFindOptions().Limit).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,164 @@
+package mongoid
+
+import (
+	"encoding/base64"
+	"fmt"
+	mongoidError "mongoid/errors"
+	"mongoid/log"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Paginate returns a single page of the documents within the collection (see Criteria.Paginate)
+func (model *ModelType) Paginate(pageSize int64, afterToken string) (*Result, string, error) {
+	log.Debugf("%v.Paginate(%v, %v)", model.GetModelName(), pageSize, afterToken)
+	return criteriaWhere(model, nil).(*criteriaStruct).Paginate(pageSize, afterToken)
+}
+
+// Paginate returns a single page of up to pageSize documents matching the criteria, along with an opaque token for requesting the next page.
+// Pass an empty afterToken for the first page, and the returned token for each page thereafter; an empty token is returned after the last page.
+//
+// Pages are located by the sort values of the last document of the previous page (keyset pagination), rather than by Skip,
+// so that each page is as fast to locate as the first. The sort order of the criteria (see OrderBy) determines the sort key,
+// which defaults to _id. When _id is not part of the sort, it is added as the final sort field so that documents with equal
+// sort values are never skipped or repeated across pages. Any Limit or Skip within the criteria is replaced.
+//
+// Since the database server only compares values of the same type when locating the next page, documents where a sort field
+// is null or missing are never returned after the first page. Only sort by fields that always hold a value.
+//
+// An error is returned if afterToken is not a token returned by Paginate for the same sort order,
+// if the criteria includes a Find or a sort by text score (see SortByTextScore), neither of which can be paginated,
+// or if the projection of the criteria (see Only and Without) does not load every sort field, since the token is built from their values.
+func (criteria *criteriaStruct) Paginate(pageSize int64, afterToken string) (*Result, string, error) {
+	log.Debugf("Criteria.Paginate(%v, %v)", pageSize, afterToken)
+	if pageSize <= 0 {
+		return nil, "", mongoidError.InvalidOperation{
+			MethodName: "Criteria.Paginate",
+			Reason:     "pageSize must be greater than zero",
+		}
+	}
+
+	if len(criteria.getFindIDs()) > 0 {
+		return nil, "", mongoidError.InvalidOperation{
+			MethodName: "Criteria.Paginate",
+			Reason:     "Criteria with Find cannot be paginated",
+		}
+	}
+
+	page := Criteria(criteria)
+	sortD := criteria.getSortBsonD()
+	for _, element := range sortD {
+		if _, ok := element.Value.(bson.D); ok { // ie, {$meta: "textScore"}
+			return nil, "", mongoidError.InvalidOperation{
+				MethodName: "Criteria.Paginate",
+				Reason:     "Criteria sorted by text score cannot be paginated",
+			}
+		}
+	}
+	if !bsonDHasKey(sortD, "_id") {
+		page = page.OrderBy("_id", Ascending)
+		sortD = append(sortD, bson.E{Key: "_id", Value: int32(Ascending)})
+	}
+	loaded := makeLoadedFields(criteria.getProjectionBsonD())
+	for _, element := range sortD {
+		if !loaded.isLoaded(element.Key) {
+			return nil, "", mongoidError.InvalidOperation{
+				MethodName: "Criteria.Paginate",
+				Reason:     fmt.Sprintf("Criteria projection must load the sort field '%v' to be paginated", element.Key),
+			}
+		}
+	}
+	if afterToken != "" {
+		afterValues, err := decodePaginationToken(afterToken, sortD)
+		if err != nil {
+			return nil, "", err
+		}
+		page = page.Where(Query{"$or": keysetFilterBsonA(sortD, afterValues)})
+	}
+
+	// one extra document is requested, to learn whether there's a next page without a second query
+	res := page.Skip(0).Limit(pageSize + 1).X()
+	if res.Count() <= uint(pageSize) {
+		return res, "", nil
+	}
+	res.truncate(uint(pageSize))
+	return res, encodePaginationToken(sortD, res.lookback[pageSize-1]), nil
+}
+
+// builds the alternatives of a keyset filter, matching the documents sorted after the given values.
+// For sort fields (a, b, c), that is: a after; or a equal and b after; or a and b equal and c after.
+func keysetFilterBsonA(sortD bson.D, afterValues bson.D) bson.A {
+	alternatives := bson.A{}
+	for i, element := range sortD {
+		alternative := bson.D{}
+		for j := 0; j < i; j++ {
+			alternative = append(alternative, bson.E{Key: sortD[j].Key, Value: afterValues[j].Value})
+		}
+		operator := "$gt"
+		if isDescendingSortValue(element.Value) {
+			operator = "$lt"
+		}
+		alternative = append(alternative, bson.E{Key: element.Key, Value: bson.M{operator: afterValues[i].Value}})
+		alternatives = append(alternatives, alternative)
+	}
+	return alternatives
+}
+
+// encodes the sort values of the given document into an opaque pagination token
+func encodePaginationToken(sortD bson.D, bsonM bson.M) string {
+	afterValues := bson.D{}
+	for _, element := range sortD {
+		afterValues = append(afterValues, bson.E{Key: element.Key, Value: getBsonMPathValue(bsonM, element.Key)})
+	}
+	tokenBytes, err := bson.Marshal(afterValues)
+	if err != nil {
+		log.Panic(err) // the values were just read from the database, so they should always marshal
+	}
+	return base64.RawURLEncoding.EncodeToString(tokenBytes)
+}
+
+// decodes the sort values within a pagination token, ensuring they are for the same sort fields as sortD
+func decodePaginationToken(token string, sortD bson.D) (bson.D, error) {
+	invalidToken := mongoidError.InvalidOperation{
+		MethodName: "Criteria.Paginate",
+		Reason:     "afterToken is not valid for this Criteria",
+	}
+	tokenBytes, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, invalidToken
+	}
+	afterValues := bson.D{}
+	if err := bson.Unmarshal(tokenBytes, &afterValues); err != nil {
+		return nil, invalidToken
+	}
+	if len(afterValues) != len(sortD) {
+		return nil, invalidToken
+	}
+	for i := range sortD {
+		if afterValues[i].Key != sortD[i].Key {
+			return nil, invalidToken
+		}
+	}
+	return afterValues, nil
+}
+
+// returns true if the given sort value sorts in descending order
+func isDescendingSortValue(value interface{}) bool {
+	switch v := value.(type) {
+	case int32:
+		return v < 0
+	case SortDirection:
+		return v < 0
+	}
+	return false
+}
+
+// returns true if the given bsonD contains the given key
+func bsonDHasKey(bsonD bson.D, key string) bool {
+	for _, element := range bsonD {
+		if element.Key == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package mongoid
+
+import (
+	mongoidError "mongoid/errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Criteria Pagination", func() {
+	Describe("keysetFilterBsonA", func() {
+		It("matches documents after a single sort value", func() {
+			sortD := bson.D{{Key: "_id", Value: int32(1)}}
+			afterValues := bson.D{{Key: "_id", Value: 5}}
+			Expect(keysetFilterBsonA(sortD, afterValues)).To(Equal(bson.A{
+				bson.D{{Key: "_id", Value: bson.M{"$gt": 5}}},
+			}))
+		})
+		It("breaks ties with each following sort field, honoring the sort direction", func() {
+			sortD := bson.D{{Key: "age", Value: int32(-1)}, {Key: "name", Value: int32(1)}, {Key: "_id", Value: int32(1)}}
+			afterValues := bson.D{{Key: "age", Value: 30}, {Key: "name", Value: "bob"}, {Key: "_id", Value: 7}}
+			Expect(keysetFilterBsonA(sortD, afterValues)).To(Equal(bson.A{
+				bson.D{{Key: "age", Value: bson.M{"$lt": 30}}},
+				bson.D{{Key: "age", Value: 30}, {Key: "name", Value: bson.M{"$gt": "bob"}}},
+				bson.D{{Key: "age", Value: 30}, {Key: "name", Value: "bob"}, {Key: "_id", Value: bson.M{"$gt": 7}}},
+			}))
+		})
+	})
+
+	Describe("pagination tokens", func() {
+		sortD := bson.D{{Key: "address.city", Value: int32(1)}, {Key: "_id", Value: int32(1)}}
+
+		It("round trip the sort values of a document", func() {
+			id := NewObjectID()
+			token := encodePaginationToken(sortD, bson.M{"_id": id, "name": "ignored", "address": bson.M{"city": "Springfield"}})
+			Expect(token).ToNot(BeEmpty())
+			afterValues, err := decodePaginationToken(token, sortD)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(afterValues).To(Equal(bson.D{{Key: "address.city", Value: "Springfield"}, {Key: "_id", Value: id}}))
+		})
+		It("are rejected when malformed", func() {
+			_, err := decodePaginationToken("not a token!", sortD)
+			Expect(err).To(BeAssignableToTypeOf(mongoidError.InvalidOperation{}))
+			_, err = decodePaginationToken("AAAA", sortD)
+			Expect(err).To(HaveOccurred())
+		})
+		It("are rejected for a different sort order", func() {
+			token := encodePaginationToken(bson.D{{Key: "_id", Value: int32(1)}}, bson.M{"_id": NewObjectID()})
+			_, err := decodePaginationToken(token, sortD)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	It("rejects a pageSize less than one", func() {
+		_, _, err := criteriaWhere(nil, nil).Paginate(0, "")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("rejects criteria with Find", func() {
+		_, _, err := criteriaWhere(nil, nil).Find(NewObjectID()).(*criteriaStruct).Paginate(10, "")
+		Expect(mongoidError.IsInvalidOperation(err)).To(BeTrue())
+	})
+
+	It("rejects criteria sorted by text score", func() {
+		_, _, err := criteriaWhere(nil, nil).SortByTextScore().(*criteriaStruct).Paginate(10, "")
+		Expect(mongoidError.IsInvalidOperation(err)).To(BeTrue())
+	})
+
+	It("rejects projections that do not load every sort field", func() {
+		_, _, err := criteriaWhere(nil, nil).Desc("age").Only("name").(*criteriaStruct).Paginate(10, "")
+		Expect(mongoidError.IsInvalidOperation(err)).To(BeTrue())
+		_, _, err = criteriaWhere(nil, nil).Desc("address.city").Without("address").(*criteriaStruct).Paginate(10, "")
+		Expect(mongoidError.IsInvalidOperation(err)).To(BeTrue())
+	})
+
+	It("raises IndexOutOfBounds beyond the end of a page", func() {
+		res := &Result{lookback: []bson.M{{"_id": 1}, {"_id": 2}, {"_id": 3}}, cursorIndex: 3, closed: true}
+		res.truncate(2)
+		Expect(res.Count()).To(Equal(uint(2)))
+		var recovered interface{}
+		func() {
+			defer func() { recovered = recover() }()
+			res.At(2)
+		}()
+		Expect(recovered).To(Equal(mongoidError.IndexOutOfBounds{}))
+	})
+})
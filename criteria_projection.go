@@ -0,0 +1,97 @@
+package mongoid
+
+import (
+	"mongoid/log"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Only restricts the loaded document fields to the given fields (plus _id).
+// Documents returned by the query will only track changes for the loaded fields, and GetField() will return a DocumentFieldNotLoaded error for the others.
+func (model *ModelType) Only(fields ...string) Criteria {
+	log.Debug("ModelType.Only ", fields)
+	return criteriaProjection(model, nil, projectionFieldsToBsonD(1, fields...))
+}
+
+// Only restricts the loaded document fields to the given fields (plus _id).
+// Documents returned by the query will only track changes for the loaded fields, and GetField() will return a DocumentFieldNotLoaded error for the others.
+func (criteria *criteriaStruct) Only(fields ...string) Criteria {
+	log.Debug("Criteria.Only ", fields)
+	return criteriaProjection(nil, criteria, projectionFieldsToBsonD(1, fields...))
+}
+
+// Without excludes the given fields from the loaded document fields. _id is always loaded, and after Only() the given fields are simply not included.
+// Documents returned by the query will not track changes for the excluded fields, and GetField() will return a DocumentFieldNotLoaded error for them.
+func (model *ModelType) Without(fields ...string) Criteria {
+	log.Debug("ModelType.Without ", fields)
+	return criteriaProjection(model, nil, projectionFieldsToBsonD(0, fields...))
+}
+
+// Without excludes the given fields from the loaded document fields. _id is always loaded, and after Only() the given fields are simply not included.
+// Documents returned by the query will not track changes for the excluded fields, and GetField() will return a DocumentFieldNotLoaded error for them.
+func (criteria *criteriaStruct) Without(fields ...string) Criteria {
+	log.Debug("Criteria.Without ", fields)
+	return criteriaProjection(nil, criteria, projectionFieldsToBsonD(0, fields...))
+}
+
+func projectionFieldsToBsonD(include int32, fields ...string) bson.D {
+	projectionD := bson.D{}
+	for _, field := range fields {
+		projectionD = append(projectionD, bson.E{Key: field, Value: include})
+	}
+	return projectionD
+}
+
+func criteriaProjection(srcModel *ModelType, prevCriteria *criteriaStruct, projection bson.D) Criteria {
+	if prevCriteria == nil {
+		prevCriteria = criteriaWhere(srcModel, nil).(*criteriaStruct)
+	}
+	log.Traceln("New Criteria.Projection ", projection)
+	newCriteria := criteriaStruct{
+		criteriaType:   projectionCriteria,
+		prevCriteria:   prevCriteria,
+		thisProjection: projection,
+	}
+	return &newCriteria
+}
+
+// builds the driver-ready projection document for the criteria chain, with field names converted to their bson equivalents
+// a field that is projected more than once takes the most recent value.
+// The server rejects projections that mix included and excluded fields, so exclusions are dropped from an inclusive projection
+// (ie, Only("name").Without("age") only loads name), and _id is never excluded, since saving or deleting a document requires its _id.
+func (criteria *criteriaStruct) getProjectionBsonD() bson.D {
+	model := criteria.getSourceModel()
+	projectionD := bson.D{}
+	for _, link := range criteria.getChain() {
+		if link.criteriaType != projectionCriteria {
+			continue
+		}
+		for _, element := range link.thisProjection {
+			key := model.GetBsonFieldPath(element.Key)
+			replaced := false
+			for i := range projectionD {
+				if projectionD[i].Key == key {
+					projectionD[i].Value = element.Value
+					replaced = true
+				}
+			}
+			if !replaced {
+				projectionD = append(projectionD, bson.E{Key: key, Value: element.Value})
+			}
+		}
+	}
+	inclusive := false
+	for _, element := range projectionD {
+		if element.Key != "_id" && !isMetaProjectionValue(element.Value) && projectionValueIncludes(element.Value) {
+			inclusive = true
+		}
+	}
+	resolvedD := bson.D{}
+	for _, element := range projectionD {
+		if !isMetaProjectionValue(element.Value) && !projectionValueIncludes(element.Value) && (inclusive || element.Key == "_id") {
+			continue
+		}
+		resolvedD = append(resolvedD, element)
+	}
+	return resolvedD
+}
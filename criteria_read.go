@@ -0,0 +1,120 @@
+package mongoid
+
+import (
+	mongoidError "mongoid/errors"
+	"mongoid/log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/tag"
+)
+
+// ReadPreferenceMode selects which members of a replica set may serve a read, as implemented by mongo-go-driver
+type ReadPreferenceMode = readpref.Mode
+
+// Read preference modes, for use with ReadPreference
+const (
+	ReadPrimary            ReadPreferenceMode = readpref.PrimaryMode            // only the primary (the default)
+	ReadPrimaryPreferred   ReadPreferenceMode = readpref.PrimaryPreferredMode   // the primary, or a secondary when the primary is unavailable
+	ReadSecondary          ReadPreferenceMode = readpref.SecondaryMode          // only secondaries
+	ReadSecondaryPreferred ReadPreferenceMode = readpref.SecondaryPreferredMode // a secondary, or the primary when no secondary is available
+	ReadNearest            ReadPreferenceMode = readpref.NearestMode            // whichever member has the lowest network latency
+)
+
+// Read concern levels, for use with ReadConcern
+const (
+	ReadConcernLocal        = "local"
+	ReadConcernAvailable    = "available"
+	ReadConcernMajority     = "majority"
+	ReadConcernLinearizable = "linearizable"
+	ReadConcernSnapshot     = "snapshot"
+)
+
+// ReadPreference sends the queries of the criteria to the replica set members allowed by the given mode (ie, ReadSecondaryPreferred for analytics).
+// When given, tagSets limit the members to those with matching tags (ie, []map[string]string{{"region": "east"}}), in order of preference,
+// and a non-zero maxStaleness excludes secondaries that have fallen further behind the primary (at least 90 seconds).
+// Panics with InvalidOperation when the combination is not allowed, such as tag sets with ReadPrimary.
+func (criteria *criteriaStruct) ReadPreference(mode ReadPreferenceMode, tagSets []map[string]string, maxStaleness time.Duration) Criteria {
+	log.Debug("Criteria.ReadPreference ", mode, tagSets, maxStaleness)
+	readPreference := makeReadPreference("Criteria.ReadPreference", mode, tagSets, maxStaleness)
+	return criteriaOptions(criteria, queryOptions{readPreference: readPreference})
+}
+
+// ReadConcern sets the consistency of the data read by the queries of the criteria, given as one of the ReadConcern levels (ie, ReadConcernMajority)
+func (criteria *criteriaStruct) ReadConcern(level string) Criteria {
+	log.Debug("Criteria.ReadConcern ", level)
+	return criteriaOptions(criteria, queryOptions{readConcern: readconcern.New(readconcern.Level(level))})
+}
+
+// SetReadPreference changes the default read preference of the ModelType's queries (see Criteria.ReadPreference)
+func (model *ModelType) SetReadPreference(mode ReadPreferenceMode, tagSets []map[string]string, maxStaleness time.Duration) *ModelType {
+	newModelType := *model // dereferenced copy
+	newModelType.readPreference = makeReadPreference("ModelType.SetReadPreference", mode, tagSets, maxStaleness)
+	// update the global registry for this ModelType
+	return mongoidModelRegistry.updateModelTypeRegistration(&newModelType)
+}
+
+// SetReadConcern changes the default read concern level of the ModelType's queries (see Criteria.ReadConcern)
+func (model *ModelType) SetReadConcern(level string) *ModelType {
+	newModelType := *model // dereferenced copy
+	newModelType.readConcern = readconcern.New(readconcern.Level(level))
+	// update the global registry for this ModelType
+	return mongoidModelRegistry.updateModelTypeRegistration(&newModelType)
+}
+
+func makeReadPreference(methodName string, mode ReadPreferenceMode, tagSets []map[string]string, maxStaleness time.Duration) *readpref.ReadPref {
+	readPrefOpts := []readpref.Option{}
+	if len(tagSets) > 0 {
+		readPrefOpts = append(readPrefOpts, readpref.WithTagSets(tag.NewTagSetsFromMaps(tagSets)...))
+	}
+	if maxStaleness > 0 {
+		readPrefOpts = append(readPrefOpts, readpref.WithMaxStaleness(maxStaleness))
+	}
+	readPreference, err := readpref.New(mode, readPrefOpts...)
+	if err != nil {
+		log.Panic(mongoidError.InvalidOperation{
+			MethodName: methodName,
+			Reason:     err.Error(),
+		})
+	}
+	return readPreference
+}
+
+// returns a handle to the mongo driver collection for this ModelType, using the read preference and read concern of the given options
+// when present, otherwise those of the ModelType
+func (model *ModelType) getReadCollectionHandle(opts queryOptions) *mongo.Collection {
+	if opts.readPreference == nil && opts.readConcern == nil {
+		return model.getMongoCollectionHandle()
+	}
+	collectionOpts := model.getCollectionOptions()
+	if opts.readPreference != nil {
+		collectionOpts.SetReadPreference(opts.readPreference)
+	}
+	if opts.readConcern != nil {
+		collectionOpts.SetReadConcern(opts.readConcern)
+	}
+	return model.GetClient().getMongoCollectionHandle(model.GetDatabaseName(), model.GetCollectionName(), collectionOpts)
+}
+
+// returns the read preference of the given options when present, otherwise that of the ModelType (nil when neither was given)
+func (model *ModelType) getReadPreference(opts queryOptions) *readpref.ReadPref {
+	if opts.readPreference != nil {
+		return opts.readPreference
+	}
+	return model.readPreference
+}
+
+// the driver collection options for the read preference and read concern of the ModelType, where given
+func (model *ModelType) getCollectionOptions() *options.CollectionOptions {
+	collectionOpts := options.Collection()
+	if model.readPreference != nil {
+		collectionOpts.SetReadPreference(model.readPreference)
+	}
+	if model.readConcern != nil {
+		collectionOpts.SetReadConcern(model.readConcern)
+	}
+	return collectionOpts
+}
@@ -0,0 +1,59 @@
+package mongoid
+
+import (
+	mongoidError "mongoid/errors"
+	"mongoid/log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Criteria read preference and read concern", func() {
+	type readTestModel struct {
+		Base
+		Name string
+	}
+
+	It("are given by the criteria, with the most recent value used", func() {
+		criteria := newCriteriaRoot(nil).ReadPreference(ReadPrimaryPreferred, nil, 0).ReadConcern(ReadConcernLocal).
+			ReadPreference(ReadSecondary, []map[string]string{{"use": "analytics"}}, 2*time.Minute).(*criteriaStruct)
+		opts := criteria.getQueryOptions()
+		Expect(opts.readPreference.Mode()).To(Equal(readpref.SecondaryMode))
+		Expect(opts.readPreference.TagSets()).To(HaveLen(1))
+		Expect(opts.readPreference.TagSets()[0].Contains("use", "analytics")).To(BeTrue())
+		maxStaleness, found := opts.readPreference.MaxStaleness()
+		Expect(found).To(BeTrue())
+		Expect(maxStaleness).To(Equal(2 * time.Minute))
+		Expect(opts.readConcern.GetLevel()).To(Equal("local"))
+	})
+
+	It("panics with InvalidOperation for combinations that are not allowed", func() {
+		Expect(func() {
+			log.WithMute(func() {
+				newCriteriaRoot(nil).ReadPreference(ReadPrimary, []map[string]string{{"use": "analytics"}}, 0)
+			})
+		}).To(PanicWith(BeAssignableToTypeOf(mongoidError.InvalidOperation{})))
+	})
+
+	It("default to those of the ModelType", func() {
+		model := Register(&readTestModel{}).SetReadPreference(ReadSecondaryPreferred, nil, 0).SetReadConcern(ReadConcernMajority)
+		collectionOpts := model.getCollectionOptions()
+		Expect(collectionOpts.ReadPreference.Mode()).To(Equal(readpref.SecondaryPreferredMode))
+		Expect(collectionOpts.ReadConcern.GetLevel()).To(Equal("majority"))
+		Expect(model.getReadPreference(queryOptions{}).Mode()).To(Equal(readpref.SecondaryPreferredMode))
+
+		criteria := model.Where(Q{"name": "bob"}).ReadPreference(ReadNearest, nil, 0).(*criteriaStruct)
+		Expect(model.getReadPreference(criteria.getQueryOptions()).Mode()).To(Equal(readpref.NearestMode))
+	})
+
+	It("are not given by default", func() {
+		model := &ModelType{modelName: "readTestModel"}
+		collectionOpts := model.getCollectionOptions()
+		Expect(collectionOpts.ReadPreference).To(BeNil())
+		Expect(collectionOpts.ReadConcern).To(BeNil())
+		Expect(model.getReadPreference(queryOptions{})).To(BeNil())
+	})
+})
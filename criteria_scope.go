@@ -0,0 +1,110 @@
+package mongoid
+
+import (
+	"fmt"
+	mongoidError "mongoid/errors"
+	"mongoid/log"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// ScopeFunc builds upon the given Criteria to produce a scoped Criteria (ie, adding Where, OrderBy, etc).
+// It must always build upon the given Criteria, rather than starting a new one from a ModelType.
+type ScopeFunc func(Criteria) Criteria
+
+// Scope adds a named scope to the ModelType, to be applied to any Criteria via Scoped(name)
+func (model *ModelType) Scope(name string, scope ScopeFunc) *ModelType {
+	newModelType := *model // dereferenced copy
+	newModelType.scopes = make(map[string]ScopeFunc, len(model.scopes)+1)
+	for k, v := range model.scopes {
+		newModelType.scopes[k] = v
+	}
+	newModelType.scopes[name] = scope
+	// update the global registry for this ModelType
+	return mongoidModelRegistry.updateModelTypeRegistration(&newModelType)
+}
+
+// SetDefaultScope changes the scope applied to every Criteria of the ModelType unless made Unscoped() (a nil scope removes it)
+// ref: https://github.com/eshork/go-mongoid/issues/17
+func (model *ModelType) SetDefaultScope(scope ScopeFunc) *ModelType {
+	newModelType := *model // dereferenced copy
+	newModelType.defaultScope = scope
+	// update the global registry for this ModelType
+	return mongoidModelRegistry.updateModelTypeRegistration(&newModelType)
+}
+
+// Scoped starts a new Criteria with the named scope applied
+func (model *ModelType) Scoped(name string) Criteria {
+	log.Debugf("%v.Scoped(%v)", model.GetModelName(), name)
+	return criteriaWhere(model, nil).Scoped(name)
+}
+
+// Scoped applies the named scope (see ModelType.Scope) to the criteria.
+// Scoped will panic with an InvalidOperation error if the ModelType has no scope with the given name.
+func (criteria *criteriaStruct) Scoped(name string) Criteria {
+	log.Debug("Criteria.Scoped ", name)
+	model := criteria.getExecutionModel("Criteria.Scoped")
+	scope, ok := model.scopes[name]
+	if !ok {
+		log.Panic(mongoidError.InvalidOperation{
+			MethodName: "Criteria.Scoped",
+			Reason:     fmt.Sprintf("%v has no scope named '%v'", model.GetModelName(), name),
+		})
+	}
+	return scope(criteria)
+}
+
+// Unscoped starts a new Criteria without the default scope of the ModelType
+func (model *ModelType) Unscoped() Criteria {
+	log.Debugf("%v.Unscoped()", model.GetModelName())
+	return newCriteriaRoot(model)
+}
+
+// Unscoped removes the default scope of the ModelType from the criteria, keeping everything else within the chain
+func (criteria *criteriaStruct) Unscoped() Criteria {
+	log.Debug("Criteria.Unscoped")
+	model := criteria.getSourceModel()
+	var prevCriteria *criteriaStruct
+	for _, link := range criteria.getChain() {
+		if link.defaultScoped {
+			continue
+		}
+		newCriteria := *link // dereferenced copy
+		newCriteria.prevCriteria = prevCriteria
+		newCriteria.sourceModel = nil
+		if prevCriteria == nil {
+			newCriteria.sourceModel = model
+		}
+		prevCriteria = &newCriteria
+	}
+	return prevCriteria
+}
+
+// returns an empty root link for a new criteria chain of the given model, without any default scope
+func newCriteriaRoot(model *ModelType) *criteriaStruct {
+	return &criteriaStruct{
+		sourceModel:    model,
+		criteriaType:   whereCriteria,
+		thisQuery:      Query{},
+		thisQueryBsonD: bson.D{},
+	}
+}
+
+// returns a new criteria chain of the given model with its default scope applied, with each of the default scope links flagged so Unscoped() can remove them.
+// The links returned by the scope are copied before being flagged, since the scope may hand back links it holds onto elsewhere.
+func newDefaultScopedCriteria(model *ModelType) *criteriaStruct {
+	root := newCriteriaRoot(model)
+	scoped := model.defaultScope(root).(*criteriaStruct)
+	var prevCriteria *criteriaStruct
+	for _, link := range scoped.getChain() {
+		if link == root {
+			prevCriteria = root
+			continue
+		}
+		newCriteria := *link // dereferenced copy
+		newCriteria.prevCriteria = prevCriteria
+		newCriteria.defaultScoped = true
+		prevCriteria = &newCriteria
+	}
+	return prevCriteria
+}
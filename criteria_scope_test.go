@@ -0,0 +1,105 @@
+package mongoid
+
+import (
+	"mongoid/log"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Criteria Scopes", func() {
+	model := &ModelType{
+		modelName: "scopeTestModel",
+		scopes: map[string]ScopeFunc{
+			"adults": func(c Criteria) Criteria { return c.Where(Q{"age.$gte": 18}) },
+		},
+		defaultScope: func(c Criteria) Criteria { return c.Where(Q{"deleted": false}).Desc("age") },
+	}
+
+	It("applies the default scope to new chains", func() {
+		criteria := model.Where(Q{"name": "bob"}).(*criteriaStruct)
+		Expect(criteria.getSourceModel()).To(BeIdenticalTo(model))
+		Expect(criteria.getFilterBsonD()).To(Equal(bson.D{
+			{Key: "deleted", Value: false},
+			{Key: "name", Value: "bob"},
+		}))
+		Expect(criteria.getSortBsonD()).To(Equal(bson.D{{Key: "age", Value: int32(-1)}}))
+	})
+
+	It("applies the default scope to chains started by other methods", func() {
+		Expect(model.Limit(1).(*criteriaStruct).getFilterBsonD()).To(Equal(bson.D{{Key: "deleted", Value: false}}))
+	})
+
+	It("applies named scopes", func() {
+		criteria := model.Where(Q{"name": "bob"}).Scoped("adults").(*criteriaStruct)
+		Expect(criteria.getFilterBsonD()).To(Equal(bson.D{
+			{Key: "deleted", Value: false},
+			{Key: "name", Value: "bob"},
+			{Key: "age", Value: bson.M{"$gte": 18}},
+		}))
+		Expect(model.Scoped("adults").(*criteriaStruct).getFilterBsonD()).To(Equal(bson.D{
+			{Key: "deleted", Value: false},
+			{Key: "age", Value: bson.M{"$gte": 18}},
+		}))
+	})
+
+	It("panics for unknown scope names", func() {
+		Expect(func() {
+			log.WithMute(func() {
+				model.Scoped("nope")
+			})
+		}).To(Panic())
+	})
+
+	It("removes only the default scope when Unscoped", func() {
+		criteria := model.Where(Q{"name": "bob"}).Scoped("adults").Unscoped().(*criteriaStruct)
+		Expect(criteria.getSourceModel()).To(BeIdenticalTo(model))
+		Expect(criteria.getFilterBsonD()).To(Equal(bson.D{
+			{Key: "name", Value: "bob"},
+			{Key: "age", Value: bson.M{"$gte": 18}},
+		}))
+		Expect(criteria.getSortBsonD()).To(BeEmpty())
+	})
+
+	It("applies the default scope to every alternative of Or", func() {
+		Expect(model.Where(Q{"name": "bob"}).Or(Q{"name": "alice"}).(*criteriaStruct).getFilterBsonD()).To(Equal(bson.D{
+			{Key: "deleted", Value: false},
+			{Key: "$or", Value: bson.A{
+				bson.D{{Key: "name", Value: "bob"}},
+				bson.D{{Key: "name", Value: "alice"}},
+			}},
+		}))
+		Expect(model.Or(Q{"name": "bob"}, Q{"name": "alice"}).(*criteriaStruct).getFilterBsonD()).To(Equal(bson.D{
+			{Key: "deleted", Value: false},
+			{Key: "$or", Value: bson.A{
+				bson.D{{Key: "name", Value: "bob"}},
+				bson.D{{Key: "name", Value: "alice"}},
+			}},
+		}))
+	})
+
+	It("does not alter the links returned by the default scope", func() {
+		var returned *criteriaStruct
+		capturingModel := &ModelType{
+			modelName: "scopeTestCapturingModel",
+			defaultScope: func(c Criteria) Criteria {
+				returned = c.Where(Q{"deleted": false}).(*criteriaStruct)
+				return returned
+			},
+		}
+		criteria := capturingModel.Where(Q{"name": "bob"}).(*criteriaStruct)
+		Expect(criteria.getFilterBsonD()).To(Equal(bson.D{
+			{Key: "deleted", Value: false},
+			{Key: "name", Value: "bob"},
+		}))
+		Expect(returned.defaultScoped).To(BeFalse())
+		Expect(criteria.Unscoped().(*criteriaStruct).getFilterBsonD()).To(Equal(bson.D{{Key: "name", Value: "bob"}}))
+	})
+
+	It("starts unscoped chains from the ModelType", func() {
+		criteria := model.Unscoped().Where(Q{"name": "bob"}).(*criteriaStruct)
+		Expect(criteria.getFilterBsonD()).To(Equal(bson.D{{Key: "name", Value: "bob"}}))
+	})
+})
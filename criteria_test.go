@@ -0,0 +1,72 @@
+package mongoid
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Criteria Chaining", func() {
+
+	Describe("mergeBsonDFilters", func() {
+		It("returns an empty filter when given nothing", func() {
+			Expect(mergeBsonDFilters()).To(Equal(bson.D{}))
+			Expect(mergeBsonDFilters(bson.D{}, bson.D{})).To(Equal(bson.D{}))
+		})
+		It("returns a lone filter unchanged", func() {
+			filter := bson.D{{Key: "a", Value: 1}}
+			Expect(mergeBsonDFilters(bson.D{}, filter)).To(Equal(filter))
+		})
+		It("concatenates filters without overlapping keys", func() {
+			merged := mergeBsonDFilters(bson.D{{Key: "a", Value: 1}}, bson.D{{Key: "b", Value: 2}})
+			Expect(merged).To(Equal(bson.D{{Key: "a", Value: 1}, {Key: "b", Value: 2}}))
+		})
+		It("uses $and when keys overlap", func() {
+			first := bson.D{{Key: "a", Value: bson.M{"$gt": 1}}}
+			second := bson.D{{Key: "a", Value: bson.M{"$lt": 5}}}
+			merged := mergeBsonDFilters(first, second)
+			Expect(merged).To(Equal(bson.D{{Key: "$and", Value: bson.A{first, second}}}))
+		})
+	})
+
+	Describe("getFilterBsonD", func() {
+		It("keeps the source model across the chain", func() {
+			model := &ModelType{modelName: "criteriaTestModel"}
+			criteria := criteriaWhere(model, nil, Q{"a": 1}).Where(Q{"b": 2}).(*criteriaStruct)
+			Expect(criteria.getSourceModel()).To(BeIdenticalTo(model))
+		})
+		It("starts a chain even without a Query", func() {
+			model := &ModelType{modelName: "criteriaTestModel"}
+			criteria := criteriaWhere(model, nil).(*criteriaStruct)
+			Expect(criteria).ToNot(BeNil())
+			Expect(criteria.getSourceModel()).To(BeIdenticalTo(model))
+			Expect(criteria.getFilterBsonD()).To(Equal(bson.D{}))
+		})
+		It("merges every link of the chain", func() {
+			criteria := criteriaWhere(nil, nil, Q{"a": 1}).Where(Q{"b.$gt": 2}).Where(Q{"c": 3}).(*criteriaStruct)
+			Expect(criteria.getChain()).To(HaveLen(3))
+			Expect(criteria.getFilterBsonD()).To(Equal(bson.D{
+				{Key: "a", Value: 1},
+				{Key: "b", Value: bson.M{"$gt": 2}},
+				{Key: "c", Value: 3},
+			}))
+		})
+	})
+
+	Describe("getPipelineBsonA", func() {
+		It("is empty for an unfiltered chain", func() {
+			criteria := criteriaWhere(nil, nil).(*criteriaStruct)
+			Expect(criteria.getPipelineBsonA()).To(Equal(bson.A{}))
+		})
+		It("selects the same documents as the chain", func() {
+			criteria := criteriaWhere(nil, nil, Q{"a": 1}).Desc("b").Skip(2).Limit(3).(*criteriaStruct)
+			Expect(criteria.getPipelineBsonA()).To(Equal(bson.A{
+				bson.D{{Key: "$match", Value: bson.D{{Key: "a", Value: 1}}}},
+				bson.D{{Key: "$sort", Value: bson.D{{Key: "b", Value: int32(-1)}}}},
+				bson.D{{Key: "$skip", Value: int64(2)}},
+				bson.D{{Key: "$limit", Value: int64(3)}},
+			}))
+		})
+	})
+})
@@ -0,0 +1,120 @@
+package mongoid
+
+import (
+	"mongoid/log"
+	"sort"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// the field name used to carry the text search score of each document from the database server, which the Result removes from every record (see Base.TextScore())
+const textScoreKey = "_mongoid_text_score"
+
+// TextSearchOptions are the optional settings of a text search (see Criteria.TextSearch)
+type TextSearchOptions struct {
+	Language           string // the language that determines the stop words and stemming rules, rather than the language of the text index
+	CaseSensitive      bool   // when true, the search is sensitive to letter case
+	DiacriticSensitive bool   // when true, the search is sensitive to diacritical marks (ie, é vs e)
+}
+
+// TextSearch starts a new Criteria matching the documents that contain the given search terms within the text index of the collection
+func (model *ModelType) TextSearch(search string, opts *TextSearchOptions) Criteria {
+	log.Debugf("%v.TextSearch(%v)", model.GetModelName(), search)
+	return criteriaTextSearch(criteriaWhere(model, nil).(*criteriaStruct), search, opts)
+}
+
+// TextSearch adds criteria matching the documents that contain the given search terms within the text index of the collection (see CreateTextIndex).
+// The search string uses the MongoDB $text syntax (ie, "coffee -shop" or "\"coffee shop\""), and opts may be nil for the defaults.
+// The relevance score of each matching document is made available via TextScore() on the resulting documents, and SortByTextScore orders by it.
+func (criteria *criteriaStruct) TextSearch(search string, opts *TextSearchOptions) Criteria {
+	log.Debug("Criteria.TextSearch ", search)
+	return criteriaTextSearch(criteria, search, opts)
+}
+
+func criteriaTextSearch(prevCriteria *criteriaStruct, search string, opts *TextSearchOptions) Criteria {
+	textD := bson.D{{Key: "$search", Value: search}}
+	if opts != nil {
+		if opts.Language != "" {
+			textD = append(textD, bson.E{Key: "$language", Value: opts.Language})
+		}
+		if opts.CaseSensitive {
+			textD = append(textD, bson.E{Key: "$caseSensitive", Value: true})
+		}
+		if opts.DiacriticSensitive {
+			textD = append(textD, bson.E{Key: "$diacriticSensitive", Value: true})
+		}
+	}
+	textCriteria := criteriaWhere(nil, prevCriteria, Query{"$text": textD}).(*criteriaStruct)
+	return criteriaProjection(nil, textCriteria, bson.D{{Key: textScoreKey, Value: textScoreMetaBsonD()}})
+}
+
+// SortByTextScore orders the results of a TextSearch by relevance, most relevant first
+func (criteria *criteriaStruct) SortByTextScore() Criteria {
+	log.Debug("Criteria.SortByTextScore")
+	return criteriaOrderBy(nil, criteria, bson.E{Key: textScoreKey, Value: textScoreMetaBsonD()})
+}
+
+func textScoreMetaBsonD() bson.D {
+	return bson.D{{Key: "$meta", Value: "textScore"}}
+}
+
+// TextIndexOptions are the optional settings of a text index (see ModelType.CreateTextIndex)
+type TextIndexOptions struct {
+	Name            string           // the name of the index, rather than the name generated by the database server
+	DefaultLanguage string           // the language that determines the stop words and stemming rules, rather than english
+	Weights         map[string]int32 // the relative significance of each field (by field name) compared to the others, rather than 1 for every field
+}
+
+// CreateTextIndex creates a text index of the given fields on the collection of the ModelType, which is required by TextSearch.
+// A collection may only have one text index, so all fields to be searched must be given at once. The fields may be given as either
+// the Go struct field names or the bson field names, and the special field name "$**" indexes every string field.
+// The name of the index is returned.
+func (model *ModelType) CreateTextIndex(opts *TextIndexOptions, fields ...string) (string, error) {
+	log.Debugf("%v.CreateTextIndex(%v)", model.GetModelName(), fields)
+	keysD := bson.D{}
+	for _, field := range fields {
+		keysD = append(keysD, bson.E{Key: model.GetBsonFieldPath(field), Value: "text"})
+	}
+	indexOpts := options.Index()
+	if opts != nil {
+		if opts.Name != "" {
+			indexOpts.SetName(opts.Name)
+		}
+		if opts.DefaultLanguage != "" {
+			indexOpts.SetDefaultLanguage(opts.DefaultLanguage)
+		}
+		if len(opts.Weights) > 0 {
+			weightsD := bson.D{}
+			for _, k := range sortedWeightKeys(opts.Weights) {
+				weightsD = append(weightsD, bson.E{Key: model.GetBsonFieldPath(k), Value: opts.Weights[k]})
+			}
+			indexOpts.SetWeights(weightsD)
+		}
+	}
+	collection := model.getMongoCollectionHandle()
+	return collection.Indexes().CreateOne(model.GetClient().Context(), mongo.IndexModel{Keys: keysD, Options: indexOpts})
+}
+
+func sortedWeightKeys(weights map[string]int32) []string {
+	keys := make([]string, 0, len(weights))
+	for k := range weights {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// TextScore returns the relevance score of the document to the text search that loaded it, and whether there was one
+func (d *Base) TextScore() (float64, bool) {
+	if d.textScore == nil {
+		return 0, false
+	}
+	return *d.textScore, true
+}
+
+// sets the text search score of the document
+func (d *Base) setTextScore(score float64) {
+	d.textScore = &score
+}
@@ -0,0 +1,51 @@
+package mongoid
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Criteria Text Search", func() {
+	It("builds a $text filter and projects the text score", func() {
+		criteria := criteriaWhere(nil, nil).TextSearch("coffee -shop", nil).(*criteriaStruct)
+		Expect(criteria.getFilterBsonD()).To(Equal(bson.D{
+			{Key: "$text", Value: bson.D{{Key: "$search", Value: "coffee -shop"}}},
+		}))
+		Expect(criteria.getProjectionBsonD()).To(Equal(bson.D{
+			{Key: textScoreKey, Value: bson.D{{Key: "$meta", Value: "textScore"}}},
+		}))
+		Expect(makeLoadedFields(criteria.getProjectionBsonD())).To(BeNil())
+	})
+
+	It("includes the given options", func() {
+		opts := &TextSearchOptions{Language: "es", CaseSensitive: true, DiacriticSensitive: true}
+		criteria := criteriaWhere(nil, nil).TextSearch("café", opts).(*criteriaStruct)
+		Expect(criteria.getFilterBsonD()).To(Equal(bson.D{
+			{Key: "$text", Value: bson.D{
+				{Key: "$search", Value: "café"},
+				{Key: "$language", Value: "es"},
+				{Key: "$caseSensitive", Value: true},
+				{Key: "$diacriticSensitive", Value: true},
+			}},
+		}))
+	})
+
+	It("sorts by the text score", func() {
+		criteria := criteriaWhere(nil, nil).TextSearch("coffee", nil).SortByTextScore().(*criteriaStruct)
+		Expect(criteria.getSortBsonD()).To(Equal(bson.D{
+			{Key: textScoreKey, Value: bson.D{{Key: "$meta", Value: "textScore"}}},
+		}))
+	})
+
+	It("exposes the text score through Base", func() {
+		doc := &Base{}
+		_, found := doc.TextScore()
+		Expect(found).To(BeFalse())
+		doc.setTextScore(1.5)
+		score, found := doc.TextScore()
+		Expect(found).To(BeTrue())
+		Expect(score).To(Equal(1.5))
+	})
+})
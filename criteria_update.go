@@ -0,0 +1,95 @@
+package mongoid
+
+import (
+	mongoidError "mongoid/errors"
+	"mongoid/log"
+
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// BatchResult reports the number of documents affected by UpdateAll or DeleteAll
+type BatchResult struct {
+	MatchedCount  int64 // the number of documents matching the criteria (UpdateAll only)
+	ModifiedCount int64 // the number of documents changed by the update, which excludes those already holding the updated values (UpdateAll only)
+	DeletedCount  int64 // the number of documents deleted (DeleteAll only)
+}
+
+// UpdateAll applies the update to every document within the collection (see Criteria.UpdateAll)
+func (model *ModelType) UpdateAll(update Updater) (BatchResult, error) {
+	log.Debugf("%v.UpdateAll()", model.GetModelName())
+	return criteriaWhere(model, nil).UpdateAll(update)
+}
+
+// UpdateAll applies the update to every document matching the criteria in a single round trip, without loading any documents.
+// The update is either a Query of update operators (ie, Q{"$inc": Q{"Visits": 1}}) or an Update (ie, Update{}.Inc("Visits", 1)).
+// The criteria may not use Skip or Limit, since the database server would ignore them and update every matching document.
+// Errors of the database server are returned rather than raised.
+func (criteria *criteriaStruct) UpdateAll(update Updater) (BatchResult, error) {
+	model := criteria.getExecutionModel("Criteria.UpdateAll")
+	log.Debugf("%v.Criteria.UpdateAll()", model.GetModelName())
+	if err := criteria.verifyBatchable("Criteria.UpdateAll"); err != nil {
+		return BatchResult{}, err
+	}
+	updateD, err := update.toUpdateBsonD(model)
+	if err != nil {
+		return BatchResult{}, err
+	}
+	if len(updateD) == 0 {
+		return BatchResult{}, mongoidError.InvalidOperation{
+			MethodName: "Criteria.UpdateAll",
+			Reason:     "update has no operators",
+		}
+	}
+
+	updateOpts := options.Update()
+	criteria.getQueryOptions().applyToUpdate(updateOpts)
+	collection := model.getMongoCollectionHandle()
+	filter := criteria.getFilterBsonD()
+	log.Debugf("collection[%s].UpdateMany %v %v", collection.Name(), filter, updateD)
+	res, err := collection.UpdateMany(model.GetClient().Context(), filter, updateD, updateOpts)
+	if err != nil {
+		return BatchResult{}, err
+	}
+	return BatchResult{MatchedCount: res.MatchedCount, ModifiedCount: res.ModifiedCount}, nil
+}
+
+// DeleteAll deletes every document within the collection (see Criteria.DeleteAll)
+func (model *ModelType) DeleteAll() (BatchResult, error) {
+	log.Debugf("%v.DeleteAll()", model.GetModelName())
+	return criteriaWhere(model, nil).DeleteAll()
+}
+
+// DeleteAll deletes every document matching the criteria in a single round trip, without loading any documents (so no document hooks are run).
+// The criteria may not use Skip or Limit, since the database server would ignore them and delete every matching document.
+// Errors of the database server are returned rather than raised.
+func (criteria *criteriaStruct) DeleteAll() (BatchResult, error) {
+	model := criteria.getExecutionModel("Criteria.DeleteAll")
+	log.Debugf("%v.Criteria.DeleteAll()", model.GetModelName())
+	if err := criteria.verifyBatchable("Criteria.DeleteAll"); err != nil {
+		return BatchResult{}, err
+	}
+
+	deleteOpts := options.Delete()
+	criteria.getQueryOptions().applyToDelete(deleteOpts)
+	collection := model.getMongoCollectionHandle()
+	filter := criteria.getFilterBsonD()
+	log.Debugf("collection[%s].DeleteMany %v", collection.Name(), filter)
+	res, err := collection.DeleteMany(model.GetClient().Context(), filter, deleteOpts)
+	if err != nil {
+		return BatchResult{}, err
+	}
+	return BatchResult{DeletedCount: res.DeletedCount}, nil
+}
+
+// returns an InvalidOperation error on behalf of methodName when the criteria selects documents in a way that batch operations cannot honor
+func (criteria *criteriaStruct) verifyBatchable(methodName string) error {
+	_, hasSkip := criteria.getNumber(skipCriteria)
+	_, hasLimit := criteria.getNumber(limitCriteria)
+	if hasSkip || hasLimit {
+		return mongoidError.InvalidOperation{
+			MethodName: methodName,
+			Reason:     "Criteria with Skip or Limit cannot be used, since every matching document would be affected",
+		}
+	}
+	return nil
+}
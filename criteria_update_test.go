@@ -0,0 +1,26 @@
+package mongoid
+
+import (
+	mongoidError "mongoid/errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Criteria batch mutation", func() {
+	model := &ModelType{modelName: "batchTestModel"}
+
+	It("rejects criteria using Skip or Limit", func() {
+		_, err := model.Where(Q{"name": "bob"}).Limit(5).UpdateAll(Update{}.Set("name", "alice"))
+		Expect(err).To(BeAssignableToTypeOf(mongoidError.InvalidOperation{}))
+		_, err = model.Skip(1).DeleteAll()
+		Expect(err).To(BeAssignableToTypeOf(mongoidError.InvalidOperation{}))
+	})
+
+	It("rejects updates without operators", func() {
+		_, err := model.Where(Q{"name": "bob"}).UpdateAll(Update{})
+		Expect(err).To(BeAssignableToTypeOf(mongoidError.InvalidOperation{}))
+		_, err = model.UpdateAll(Q{})
+		Expect(err).To(BeAssignableToTypeOf(mongoidError.InvalidOperation{}))
+	})
+})
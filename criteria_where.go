@@ -1,10 +1,15 @@
 package mongoid
 
 import (
+	mongoidError "mongoid/errors"
 	"mongoid/log"
 	// "fmt"
-	"go.mongodb.org/mongo-driver/bson"
+	"reflect"
+	"regexp"
 	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // Where adds criteria that must be matched in order to return results
@@ -20,6 +25,18 @@ func (criteria *criteriaStruct) Where(where ...Query) Criteria {
 }
 
 func criteriaWhere(srcModel *ModelType, prevCriteria *criteriaStruct, where ...Query) Criteria {
+	if prevCriteria == nil && srcModel != nil && srcModel.defaultScope != nil {
+		// new chains of a model with a default scope start with that scope, and the model is already held by its root link
+		prevCriteria = newDefaultScopedCriteria(srcModel)
+		srcModel = nil
+		if len(where) == 0 {
+			return prevCriteria
+		}
+	}
+	if prevCriteria == nil && len(where) == 0 {
+		// always start a new chain with at least one link, so the source model is not lost
+		where = []Query{{}}
+	}
 	curPrevCriteria := prevCriteria
 	for _, thisWhereQuery := range where {
 		log.Traceln("New Criteria.Where ", thisWhereQuery)
@@ -42,26 +59,108 @@ func criteriaWhereToBsonD(where *criteriaStruct) bson.D {
 	if where == nil {
 		return bson.D{}
 	}
-	var bsonD bson.D
-	switch len(where.thisQuery) {
-	case 0:
-		bsonD = bson.D{}
-	default:
-		bsonD = bson.D{}
-		for k, v := range where.thisQuery {
-			element := normalizeQueryElement(k, v)
-			bsonE := bson.E{
-				Key:   element[0].Key,
-				Value: element[0].Value,
-			}
-			bsonD = append(bsonD, bsonE)
+	return queryToBsonD(where.thisQuery)
+}
+
+// converts a Query into a driver-ready bson.D, normalizing the operator suffixes of each field key (see normalizeQueryElement).
+// Keys are processed in sorted order so the output is stable, and multiple operators given for the same field are combined into one entry.
+// The logical operators ($and, $or, $nor) may be used as keys with a list of nested Query values, which are converted the same way.
+func queryToBsonD(query Query) bson.D {
+	bsonD := bson.D{}
+	for _, k := range sortedQueryKeys(query) {
+		v := query[k]
+		var element bson.D
+		switch k {
+		case "$and", "$or", "$nor":
+			element = bson.D{{Key: k, Value: queryListToBsonA(v)}}
+		default:
+			// other top-level operators ($expr, $text, etc) and the operators of nested queries ($elemMatch, $not) pass through as-is
+			element = normalizeQueryElement(k, v)
 		}
+		bsonD = appendQueryElement(bsonD, element[0])
 	}
 	// log.Tracef("bsonD %+v\n", bsonD)
 	return bsonD
+}
 
+// appends the element to the bsonD, merging operator maps when the field is already present (ie, "age.$gt" and "age.$lt").
+// A plain value merged with operators becomes an $eq operator (ie, "age" and "age.$gt" give {age: {$eq: 5, $gt: 3}}), or $regex for a regular expression.
+func appendQueryElement(bsonD bson.D, element bson.E) bson.D {
+	for i := range bsonD {
+		if bsonD[i].Key != element.Key {
+			continue
+		}
+		if !isOperatorDocument(bsonD[i].Value) && !isOperatorDocument(element.Value) {
+			break // two plain values cannot be merged, so leave them for the server to reject
+		}
+		mergedOps := bson.M{}
+		for _, value := range []interface{}{bsonD[i].Value, element.Value} {
+			for op, opValue := range queryValueOperators(value) {
+				mergedOps[op] = opValue
+			}
+		}
+		bsonD[i].Value = mergedOps
+		return bsonD
+	}
+	return append(bsonD, element)
 }
 
+// returns the operators of an operator document as a bson.M, or the operator that matches a plain value
+func queryValueOperators(value interface{}) bson.M {
+	if !isOperatorDocument(value) {
+		if _, isRegex := value.(primitive.Regex); isRegex {
+			return bson.M{"$regex": value}
+		}
+		return bson.M{"$eq": value}
+	}
+	ops := bson.M{}
+	switch v := value.(type) {
+	case bson.M:
+		for op, opValue := range v {
+			ops[op] = opValue
+		}
+	case Query:
+		for op, opValue := range v {
+			ops[op] = opValue
+		}
+	case bson.D:
+		for _, opElement := range v {
+			ops[opElement.Key] = opElement.Value
+		}
+	}
+	return ops
+}
+
+// converts a list of nested queries (ie, the value of an $or) into a bson.A of driver-ready filters
+func queryListToBsonA(queryList interface{}) bson.A {
+	bsonA := bson.A{}
+	listValue := reflect.ValueOf(queryList)
+	if listValue.Kind() != reflect.Slice && listValue.Kind() != reflect.Array {
+		// not a list, so hand it over as-is and let the server explain what went wrong
+		return bson.A{queryList}
+	}
+	for i := 0; i < listValue.Len(); i++ {
+		bsonA = append(bsonA, queryValueToBsonD(listValue.Index(i).Interface()))
+	}
+	return bsonA
+}
+
+// converts a single nested query of any of the supported map types into a driver-ready filter
+func queryValueToBsonD(queryValue interface{}) interface{} {
+	switch q := queryValue.(type) {
+	case Query:
+		return queryToBsonD(q)
+	case bson.M:
+		return queryToBsonD(Query(q))
+	case map[string]interface{}:
+		return queryToBsonD(Query(q))
+	}
+	return queryValue // bson.D and anything else are assumed to already be driver-ready
+}
+
+// converts a single query key/value pair into a driver-ready bson.D, extracting any trailing operator from the key (ie, "age.$gt")
+// and embedding the value within a map keyed by that operator (ie, {"age": {"$gt": value}}).
+// Unrecognized operators will panic with an InvalidQueryOperator error, rather than building a query that can never match.
 func normalizeQueryElement(fieldKey string, fieldValue interface{}) bson.D {
 	// find operator
 	operatorStart := strings.LastIndex(fieldKey, ".$")
@@ -70,37 +169,47 @@ func normalizeQueryElement(fieldKey string, fieldValue interface{}) bson.D {
 		return bson.D{{Key: fieldKey, Value: fieldValue}}
 	}
 
-	operator := fieldKey[operatorStart:]
+	// extract the operator (less the . separator)
+	operator := fieldKey[operatorStart+1:]
+	// remove the operator from the field name
+	fieldKey = fieldKey[:operatorStart]
+
 	switch operator {
-	case ".$eq":
-		fallthrough
-	case ".$gt":
-		fallthrough
-	case ".$gte":
-		fallthrough
-	case ".$in":
-		fallthrough
-	case ".$lt":
-		fallthrough
-	case ".$lte":
-		fallthrough
-	case ".$ne":
-		fallthrough
-	case ".$nin":
-		fallthrough
-	case ".$exists":
-		// extract the operator (less the . separator)
-		operator = operator[1:]
-		// remove the operator from the field name
-		fieldKey = fieldKey[:operatorStart]
+	case "$eq", "$gt", "$gte", "$in", "$lt", "$lte", "$ne", "$nin",
+		"$exists", "$type", "$all", "$size", "$mod",
+		"$bitsAllClear", "$bitsAllSet", "$bitsAnyClear", "$bitsAnySet":
 		// reposition the fieldValue into a map
 		fieldValue = bson.M{operator: fieldValue}
+	case "$regex":
+		fieldValue = normalizeRegexValue(fieldValue)
+	case "$elemMatch", "$not":
+		// these take a nested query, which needs the same normalization
+		fieldValue = bson.M{operator: queryValueToBsonD(fieldValue)}
+	default:
+		log.Panic(mongoidError.InvalidQueryOperator{
+			FieldName: fieldKey,
+			Operator:  operator,
+		})
 	}
 
 	// return the current state
 	return bson.D{{Key: fieldKey, Value: fieldValue}}
 }
 
+// builds the $regex operator map for the given value, which may be a pattern string, a Regex (pattern with options), or a *regexp.Regexp
+func normalizeRegexValue(regexValue interface{}) bson.M {
+	switch v := regexValue.(type) {
+	case Regex:
+		if v.Options != "" {
+			return bson.M{"$regex": v.Pattern, "$options": v.Options}
+		}
+		return bson.M{"$regex": v.Pattern}
+	case *regexp.Regexp:
+		return bson.M{"$regex": v.String()}
+	}
+	return bson.M{"$regex": regexValue}
+}
+
 // https://docs.mongodb.com/manual/tutorial/query-documents/
 /*
 // Equality
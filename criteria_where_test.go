@@ -2,6 +2,10 @@ package mongoid
 
 import (
 	// "fmt"
+	mongoidError "mongoid/errors"
+	"mongoid/log"
+	"regexp"
+
 	"go.mongodb.org/mongo-driver/bson"
 	// "strconv"
 
@@ -118,6 +122,14 @@ var _ = Describe("Criteria Building", func() {
 						standardOperatorTests("$in", fieldNameDesc, fieldName, fieldValueDesc, fieldValue)
 						standardOperatorTests("$nin", fieldNameDesc, fieldName, fieldValueDesc, fieldValue)
 						standardOperatorTests("$exists", fieldNameDesc, fieldName, fieldValueDesc, fieldValue)
+						standardOperatorTests("$type", fieldNameDesc, fieldName, fieldValueDesc, fieldValue)
+						standardOperatorTests("$all", fieldNameDesc, fieldName, fieldValueDesc, fieldValue)
+						standardOperatorTests("$size", fieldNameDesc, fieldName, fieldValueDesc, fieldValue)
+						standardOperatorTests("$mod", fieldNameDesc, fieldName, fieldValueDesc, fieldValue)
+						standardOperatorTests("$bitsAllClear", fieldNameDesc, fieldName, fieldValueDesc, fieldValue)
+						standardOperatorTests("$bitsAllSet", fieldNameDesc, fieldName, fieldValueDesc, fieldValue)
+						standardOperatorTests("$bitsAnyClear", fieldNameDesc, fieldName, fieldValueDesc, fieldValue)
+						standardOperatorTests("$bitsAnySet", fieldNameDesc, fieldName, fieldValueDesc, fieldValue)
 
 						Context("No operator", func() {
 							It("returns the unaltered field name \""+fieldName+"\"", func() {
@@ -137,6 +149,67 @@ var _ = Describe("Criteria Building", func() {
 			}
 		}
 
+		Context(".$regex", func() {
+			It("accepts a pattern string", func() {
+				ret := normalizeQueryElement("field.$regex", "^abc")
+				Expect(ret).To(Equal(bson.D{{Key: "field", Value: bson.M{"$regex": "^abc"}}}))
+			})
+			It("accepts a Regex with options", func() {
+				ret := normalizeQueryElement("field.$regex", Regex{Pattern: "^abc", Options: "i"})
+				Expect(ret).To(Equal(bson.D{{Key: "field", Value: bson.M{"$regex": "^abc", "$options": "i"}}}))
+			})
+			It("accepts a *regexp.Regexp", func() {
+				ret := normalizeQueryElement("field.$regex", regexp.MustCompile("^a+bc"))
+				Expect(ret).To(Equal(bson.D{{Key: "field", Value: bson.M{"$regex": "^a+bc"}}}))
+			})
+		})
+
+		Context(".$elemMatch", func() {
+			It("normalizes the nested Query", func() {
+				ret := normalizeQueryElement("field.$elemMatch", Q{"score.$gte": 80, "product": "xyz"})
+				Expect(ret).To(Equal(bson.D{{Key: "field", Value: bson.M{"$elemMatch": bson.D{
+					{Key: "product", Value: "xyz"},
+					{Key: "score", Value: bson.M{"$gte": 80}},
+				}}}}))
+			})
+		})
+
+		Context(".$not", func() {
+			It("normalizes the nested operator Query", func() {
+				ret := normalizeQueryElement("field.$not", Q{"$gt": 5})
+				Expect(ret).To(Equal(bson.D{{Key: "field", Value: bson.M{"$not": bson.D{{Key: "$gt", Value: 5}}}}}))
+			})
+		})
+
+		Context("unknown operator", func() {
+			It("panics with InvalidQueryOperator", func() {
+				defer func() {
+					err, _ := recover().(error)
+					Expect(mongoidError.IsInvalidQueryOperator(err)).To(BeTrue())
+				}()
+				log.WithMute(func() {
+					normalizeQueryElement("field.$nope", 1)
+				})
+			})
+		})
+
+		Context("a field with both a plain value and operators", func() {
+			It("merges the plain value as $eq", func() {
+				Expect(queryToBsonD(Q{"age": 5, "age.$gt": 3})).To(Equal(bson.D{{Key: "age", Value: bson.M{"$eq": 5, "$gt": 3}}}))
+			})
+			It("merges embedded documents as $eq, rather than as operators", func() {
+				addr := bson.M{"city": "x"}
+				Expect(queryToBsonD(Q{"addr": addr, "addr.$ne": nil})).To(Equal(bson.D{{Key: "addr", Value: bson.M{"$eq": addr, "$ne": nil}}}))
+			})
+		})
+
+		Context("$expr", func() {
+			It("is passed through as-is", func() {
+				expr := bson.M{"$gt": bson.A{"$spent", "$budget"}}
+				Expect(queryToBsonD(Q{"$expr": expr})).To(Equal(bson.D{{Key: "$expr", Value: expr}}))
+			})
+		})
+
 	})
 
 })
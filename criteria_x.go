@@ -2,14 +2,32 @@ package mongoid
 
 import (
 	"mongoid/log"
-	// "strconv"
-	// "go.mongodb.org/mongo-driver/bson"
 )
 
-// THIS SHOULD BE THE FILE WHERE INITAIL CRITERIA->RESULT IMPLEMENTATION GOES
-
-// X will force eXecution of the criteria query, caching results of the Criteria
+// X will force eXecution of the criteria query, caching results of the Criteria.
+// When the criteria includes a Find, X will panic with an IDsNotFound error unless every requested id was found.
 func (criteria *criteriaStruct) X() *Result {
-	log.Panicf("NYI - Criteria.X()")
-	return nil
+	model := criteria.getExecutionModel("Criteria.X")
+	log.Debugf("%v.Criteria.X()", model.GetModelName())
+	res := criteria.execute(model)
+	criteria.verifyFindIDs(res)
+	return res
+}
+
+// runs the find for the criteria against the collection of the given model
+func (criteria *criteriaStruct) execute(model *ModelType) *Result {
+	ctx := model.GetClient().Context()
+	filter := criteria.getFilterBsonD()
+	findOpts := criteria.getFindOptions()
+
+	collection := model.getReadCollectionHandle(criteria.getQueryOptions())
+	log.Debugf("collection[%s].Find %v %+v", collection.Name(), filter, findOpts)
+	cur, err := collection.Find(ctx, filter, findOpts)
+	if err != nil {
+		// same as ModelType.find, no one has yet looked to see which of these errors might be recoverable
+		log.Panic(err) // unknown bad stuff happened within the driver
+	}
+	res := makeResult(ctx, cur, model)
+	res.loaded = makeLoadedFields(criteria.getProjectionBsonD())
+	return res
 }
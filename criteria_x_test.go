@@ -0,0 +1,358 @@
+package mongoid_test
+
+import (
+	"mongoid"
+	mongoidError "mongoid/errors"
+
+	gofakeit "github.com/brianvoe/gofakeit/v6"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type CriteriaTestModel struct {
+	mongoid.Base
+	ID     mongoid.ObjectID `bson:"_id"`
+	Name   string
+	Group  string
+	Number int
+}
+
+var CriteriaTestModels = mongoid.Register(&CriteriaTestModel{})
+
+// saves a new CriteriaTestModel record with the given values
+func createCriteriaTestModel(name, group string, number int) *CriteriaTestModel {
+	doc := CriteriaTestModels.New().(*CriteriaTestModel)
+	doc.Name = name
+	doc.Group = group
+	doc.Number = number
+	ExpectWithOffset(1, doc.Save()).To(Succeed())
+	return doc
+}
+
+var _ = Describe("Criteria", func() {
+	Context(".X()", func() {
+		It("returns the records matching all chained Where() queries", func() {
+			OnlineDatabaseOnly(func() {
+				group := gofakeit.UUID()
+				match := createCriteriaTestModel("match", group, 5)
+				createCriteriaTestModel("wrong name", group, 5)
+				createCriteriaTestModel("match", group, 1)
+				createCriteriaTestModel("match", gofakeit.UUID(), 5)
+
+				res := CriteriaTestModels.Where(mongoid.Q{"group": group}).Where(mongoid.Q{"name": "match"}).Where(mongoid.Q{"number.$gt": 2}).X()
+				Expect(res).To(BeAssignableToTypeOf(&mongoid.Result{}))
+				found := res.One().(*CriteriaTestModel)
+				Expect(found.ID).To(Equal(match.ID))
+			})
+		})
+		It("returns all records of a group with a single Where()", func() {
+			OnlineDatabaseOnly(func() {
+				group := gofakeit.UUID()
+				createCriteriaTestModel("one", group, 1)
+				createCriteriaTestModel("two", group, 2)
+				Expect(CriteriaTestModels.Where(mongoid.Q{"group": group}).X().Count()).To(Equal(uint(2)))
+			})
+		})
+		It("sorts, skips and limits the results", func() {
+			OnlineDatabaseOnly(func() {
+				group := gofakeit.UUID()
+				for i := 1; i <= 5; i++ {
+					createCriteriaTestModel(gofakeit.HipsterWord(), group, i)
+				}
+				res := CriteriaTestModels.Where(mongoid.Q{"group": group}).Desc("Number").Skip(1).Limit(2).X()
+				Expect(res.Count()).To(Equal(uint(2)))
+				Expect(res.At(0).(*CriteriaTestModel).Number).To(Equal(4))
+				Expect(res.At(1).(*CriteriaTestModel).Number).To(Equal(3))
+			})
+		})
+	})
+})
+
+var _ = Describe("Criteria", func() {
+	Context(".X()", func() {
+		It("returns persisted documents, which Save() updates in place", func() {
+			OnlineDatabaseOnly(func() {
+				group := gofakeit.UUID()
+				createCriteriaTestModel("stored", group, 1)
+
+				found := CriteriaTestModels.Where(mongoid.Q{"group": group}).X().One().(*CriteriaTestModel)
+				Expect(found.IsPersisted()).To(BeTrue())
+				found.Number = 2
+				Expect(found.Save()).To(Succeed())
+				Expect(CriteriaTestModels.Where(mongoid.Q{"group": group}).Count()).To(Equal(int64(1)))
+				Expect(CriteriaTestModels.Where(mongoid.Q{"group": group, "number": 2}).Count()).To(Equal(int64(1)))
+			})
+		})
+	})
+	Context(".Only()", func() {
+		It("loads only the given fields, and saves only loaded fields", func() {
+			OnlineDatabaseOnly(func() {
+				group := gofakeit.UUID()
+				original := createCriteriaTestModel("projected", group, 42)
+
+				found := CriteriaTestModels.Where(mongoid.Q{"group": group}).Only("Name").X().One().(*CriteriaTestModel)
+				Expect(found.ID).To(Equal(original.ID))
+				Expect(found.Name).To(Equal("projected"))
+				Expect(found.Number).To(Equal(0))
+				_, err := found.GetField("number")
+				Expect(mongoidError.IsDocumentFieldNotLoaded(err)).To(BeTrue())
+
+				found.Name = "renamed"
+				Expect(found.Changes()).To(Equal(mongoid.BsonDocument{"name": "renamed"}))
+				Expect(found.Save()).To(Succeed())
+
+				reloaded := CriteriaTestModels.Find(original.ID).One().(*CriteriaTestModel)
+				Expect(reloaded.Name).To(Equal("renamed"))
+				Expect(reloaded.Number).To(Equal(42))
+				Expect(reloaded.Group).To(Equal(group))
+			})
+		})
+	})
+})
+
+var _ = Describe("Criteria", func() {
+	Context(".Find()", func() {
+		It("composes with other criteria", func() {
+			OnlineDatabaseOnly(func() {
+				group := gofakeit.UUID()
+				match := createCriteriaTestModel("match", group, 1)
+				other := createCriteriaTestModel("other", group, 2)
+				res := CriteriaTestModels.Where(mongoid.Q{"group": group}).Find(match.ID, other.ID).Where(mongoid.Q{"name": "match"})
+				Expect(func() { res.X() }).To(Panic())
+				res = CriteriaTestModels.Where(mongoid.Q{"group": group}).Find(match.ID, other.ID)
+				Expect(res.X().Count()).To(Equal(uint(2)))
+			})
+		})
+		It("panics with an IDsNotFound listing the missing ids", func() {
+			OnlineDatabaseOnly(func() {
+				match := createCriteriaTestModel("match", gofakeit.UUID(), 1)
+				missingID := mongoid.NewObjectID()
+				var recovered interface{}
+				func() {
+					defer func() { recovered = recover() }()
+					CriteriaTestModels.Where().Find(match.ID, missingID).X()
+				}()
+				Expect(recovered).To(BeAssignableToTypeOf(mongoidError.IDsNotFound{}))
+				Expect(recovered.(mongoidError.IDsNotFound).MissingIDs).To(Equal([]interface{}{missingID}))
+			})
+		})
+	})
+})
+
+var _ = Describe("Criteria", func() {
+	Context(".Paginate()", func() {
+		It("returns every record exactly once across the pages, even with tied sort values", func() {
+			OnlineDatabaseOnly(func() {
+				group := gofakeit.UUID()
+				for i := 1; i <= 7; i++ {
+					createCriteriaTestModel(gofakeit.HipsterWord(), group, i%3)
+				}
+				criteria := CriteriaTestModels.Where(mongoid.Q{"group": group}).Desc("Number")
+				seen := map[mongoid.ObjectID]bool{}
+				prevNumber := 3
+				token := ""
+				pages := 0
+				for {
+					res, nextToken, err := criteria.Paginate(3, token)
+					Expect(err).ToNot(HaveOccurred())
+					pages++
+					Expect(res.ForEach(func(doc mongoid.IDocumentBase) error {
+						record := doc.(*CriteriaTestModel)
+						Expect(seen).ToNot(HaveKey(record.ID))
+						Expect(record.Number).To(BeNumerically("<=", prevNumber))
+						seen[record.ID] = true
+						prevNumber = record.Number
+						return nil
+					})).To(Succeed())
+					if nextToken == "" {
+						break
+					}
+					token = nextToken
+				}
+				Expect(pages).To(Equal(3))
+				Expect(seen).To(HaveLen(7))
+			})
+		})
+	})
+})
+
+var _ = Describe("Criteria", func() {
+	Context(".First() and .Last()", func() {
+		It("return the first and last records by _id", func() {
+			OnlineDatabaseOnly(func() {
+				group := gofakeit.UUID()
+				first := createCriteriaTestModel("first", group, 2)
+				createCriteriaTestModel("middle", group, 3)
+				last := createCriteriaTestModel("last", group, 1)
+				criteria := CriteriaTestModels.Where(mongoid.Q{"group": group})
+				doc, err := criteria.First()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(doc.(*CriteriaTestModel).ID).To(Equal(first.ID))
+				doc, err = criteria.Last()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(doc.(*CriteriaTestModel).ID).To(Equal(last.ID))
+			})
+		})
+		It("follow the order of the criteria", func() {
+			OnlineDatabaseOnly(func() {
+				group := gofakeit.UUID()
+				createCriteriaTestModel("a", group, 2)
+				high := createCriteriaTestModel("b", group, 3)
+				low := createCriteriaTestModel("c", group, 1)
+				criteria := CriteriaTestModels.Where(mongoid.Q{"group": group}).Desc("Number")
+				doc, _ := criteria.First()
+				Expect(doc.(*CriteriaTestModel).ID).To(Equal(high.ID))
+				doc, _ = criteria.Last()
+				Expect(doc.(*CriteriaTestModel).ID).To(Equal(low.ID))
+			})
+		})
+		It("return the last record within the Skip and Limit of the criteria", func() {
+			OnlineDatabaseOnly(func() {
+				group := gofakeit.UUID()
+				docs := []*CriteriaTestModel{}
+				for i := 1; i <= 5; i++ {
+					docs = append(docs, createCriteriaTestModel(gofakeit.HipsterWord(), group, i))
+				}
+				criteria := CriteriaTestModels.Where(mongoid.Q{"group": group}).Asc("Number")
+				doc, err := criteria.Skip(1).Limit(2).Last()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(doc.(*CriteriaTestModel).ID).To(Equal(docs[2].ID))
+				doc, err = criteria.Skip(3).Last()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(doc.(*CriteriaTestModel).ID).To(Equal(docs[4].ID))
+			})
+		})
+		It("return ResultNotFound when nothing matches", func() {
+			OnlineDatabaseOnly(func() {
+				doc, err := CriteriaTestModels.Where(mongoid.Q{"group": gofakeit.UUID()}).First()
+				Expect(doc).To(BeNil())
+				Expect(mongoidError.IsResultNotFound(err)).To(BeTrue())
+			})
+		})
+	})
+})
+
+var _ = Describe("Criteria", func() {
+	Context(".TextSearch()", func() {
+		It("finds records by text, ordered by relevance", func() {
+			OnlineDatabaseOnly(func() {
+				_, err := CriteriaTestModels.CreateTextIndex(nil, "Name")
+				Expect(err).ToNot(HaveOccurred())
+				group := gofakeit.UUID()
+				createCriteriaTestModel("coffee", group, 1)
+				best := createCriteriaTestModel("coffee coffee coffee", group, 2)
+				createCriteriaTestModel("tea", group, 3)
+
+				res := CriteriaTestModels.Where(mongoid.Q{"group": group}).TextSearch("coffee", nil).SortByTextScore().X()
+				Expect(res.Count()).To(Equal(uint(2)))
+				first := res.First().(*CriteriaTestModel)
+				Expect(first.ID).To(Equal(best.ID))
+				score, found := first.TextScore()
+				Expect(found).To(BeTrue())
+				Expect(score).To(BeNumerically(">", 0))
+				Expect(first.IsChanged()).To(BeFalse())
+				for _, record := range res.ToBsonAry() {
+					Expect(record).ToNot(HaveKey("_mongoid_text_score"))
+				}
+
+				streamed := CriteriaTestModels.Where(mongoid.Q{"group": group}).TextSearch("coffee", nil).X().Streaming()
+				Expect(streamed.ForEach(func(doc mongoid.IDocumentBase) error {
+					_, found := doc.TextScore()
+					Expect(found).To(BeTrue())
+					return nil
+				})).To(Succeed())
+			})
+		})
+	})
+})
+
+var _ = Describe("Criteria", func() {
+	Context(".Explain()", func() {
+		It("reports a collection scan for unindexed fields", func() {
+			OnlineDatabaseOnly(func() {
+				group := gofakeit.UUID()
+				createCriteriaTestModel("explained", group, 1)
+				explain, err := CriteriaTestModels.Where(mongoid.Q{"group": group}).Explain(mongoid.ExplainExecutionStats)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(explain.IsCollScan).To(BeTrue())
+				Expect(explain.IndexName).To(BeEmpty())
+				Expect(explain.DocsReturned).To(Equal(int64(1)))
+				Expect(explain.DocsExamined).To(BeNumerically(">=", explain.DocsReturned))
+			})
+		})
+		It("reports the index used", func() {
+			OnlineDatabaseOnly(func() {
+				doc := createCriteriaTestModel("explained", gofakeit.UUID(), 1)
+				explain, err := CriteriaTestModels.Where(mongoid.Q{"_id": doc.ID}).Explain(mongoid.ExplainQueryPlanner)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(explain.IsCollScan).To(BeFalse())
+				Expect(explain.WinningStage).ToNot(BeEmpty())
+			})
+		})
+	})
+})
+
+var _ = Describe("Criteria", func() {
+	Context(".Collation()", func() {
+		It("compares strings using the collation", func() {
+			OnlineDatabaseOnly(func() {
+				group := gofakeit.UUID()
+				createCriteriaTestModel("Collated", group, 1)
+				criteria := CriteriaTestModels.Where(mongoid.Q{"group": group, "name": "collated"})
+				Expect(criteria.Count()).To(Equal(int64(0)))
+				collated := criteria.Collation(mongoid.Collation{Locale: "en", Strength: 2})
+				Expect(collated.Count()).To(Equal(int64(1)))
+				Expect(collated.X().Count()).To(Equal(uint(1)))
+			})
+		})
+	})
+})
+
+var _ = Describe("Criteria", func() {
+	Context(".UpdateAll()", func() {
+		It("updates every matching document", func() {
+			OnlineDatabaseOnly(func() {
+				group := gofakeit.UUID()
+				createCriteriaTestModel("a", group, 1)
+				createCriteriaTestModel("b", group, 2)
+				createCriteriaTestModel("c", group, 3)
+				result, err := CriteriaTestModels.Where(mongoid.Q{"group": group, "number.$gte": 2}).UpdateAll(mongoid.Update{}.Inc("Number", 10))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.MatchedCount).To(Equal(int64(2)))
+				Expect(result.ModifiedCount).To(Equal(int64(2)))
+				Expect(CriteriaTestModels.Where(mongoid.Q{"group": group}).Sum("Number")).To(Equal(26))
+			})
+		})
+		It("accepts a Query of update operators", func() {
+			OnlineDatabaseOnly(func() {
+				group := gofakeit.UUID()
+				createCriteriaTestModel("a", group, 1)
+				result, err := CriteriaTestModels.Where(mongoid.Q{"group": group}).UpdateAll(mongoid.Q{"$set": mongoid.Q{"Name": "renamed"}})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.ModifiedCount).To(Equal(int64(1)))
+				Expect(CriteriaTestModels.Where(mongoid.Q{"group": group, "name": "renamed"}).Count()).To(Equal(int64(1)))
+			})
+		})
+		It("returns errors of the database server", func() {
+			OnlineDatabaseOnly(func() {
+				_, err := CriteriaTestModels.Where(mongoid.Q{"group": gofakeit.UUID()}).UpdateAll(mongoid.Q{"$notAnOperator": mongoid.Q{"name": 1}})
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Context(".DeleteAll()", func() {
+		It("deletes every matching document", func() {
+			OnlineDatabaseOnly(func() {
+				group := gofakeit.UUID()
+				createCriteriaTestModel("a", group, 1)
+				createCriteriaTestModel("b", group, 2)
+				createCriteriaTestModel("c", group, 3)
+				result, err := CriteriaTestModels.Where(mongoid.Q{"group": group, "number.$lt": 3}).DeleteAll()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.DeletedCount).To(Equal(int64(2)))
+				Expect(CriteriaTestModels.Where(mongoid.Q{"group": group}).Count()).To(Equal(int64(1)))
+			})
+		})
+	})
+})
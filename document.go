@@ -17,10 +17,17 @@ type IDocumentBase interface {
 
 	IsPersisted() bool
 	setPersisted(bool)
+	setLoadedFields(*loadedFields)
+	TextScore() (float64, bool)
+	setTextScore(float64)
 	IsChanged() bool
 	Changes() BsonDocument
 
 	Save() error
+	Reload() error
+	Delete() error
+	Destroy() error
+	IsDestroyed() bool
 
 	// SetCollection(*mgo.Collection)
 	// SetDocument(document IDocumentBase)
@@ -44,6 +51,9 @@ type Base struct {
 	rootTypeRef   IDocumentBase // self-reference for future type recognition via interface{}
 	persisted     bool          // persistence tracking (reflects the anticipated existence of a record within the datastore, based on the lifecycle of the instance)
 	previousValue BsonDocument  // stores a BSON representation of the last values, used for change tracking
+	loaded        *loadedFields // the fields loaded from the datastore when a query projection was used (nil when all fields were loaded)
+	textScore     *float64      // the relevance score of the text search that loaded the document (nil when not loaded by a text search)
+	destroyed     bool          // true once the record has been deleted from the datastore, after which the instance cannot be saved
 
 	// privateID     string       // internal object ID tracker (string form in case a custom ID field is provided of a non-ObjectID type)
 }
@@ -8,23 +8,25 @@ import (
 	mongoidError "mongoid/errors"
 	"mongoid/log"
 	"reflect"
+	"strings"
 
 	"github.com/iancoleman/strcase"
 )
 
-// GetFieldPrevious returns an interface to the previous value from the document found at the given fieldNamePath and a true boolean if the path was valid
+// GetFieldPrevious returns an interface to the previous value from the document found at the given fieldNamePath and a true boolean if the path was valid.
+// Fields of embedded documents may be given by their dotted path (ie, "address.city").
 func (d *Base) GetFieldPrevious(fieldNamePath string) (interface{}, bool) {
 	log.Tracef("GetFieldPrevious(%s)", fieldNamePath)
-	// this doesn't chase into sub-documents, will probably want to fix that one day
-	if value, found := d.previousValue[fieldNamePath]; found {
-		return value, true
-	}
-	return nil, false
+	return getBsonMValueByPath(d.previousValue, fieldNamePath)
 }
 
 // SetField sets a value on the document via bson field name path
+// Returns a DocumentFieldNotLoaded error for fields that were not loaded from the datastore (see Criteria.Only() and Criteria.Without()).
 func (d *Base) SetField(fieldNamePath string, newValue interface{}) error {
 	log.Debugf("%v.SetField(%s)", d.Model().modelName, fieldNamePath)
+	if !d.loaded.isLoaded(fieldNamePath) {
+		return &mongoidError.DocumentFieldNotLoaded{FieldName: fieldNamePath}
+	}
 	// get a Value handle to the field we want
 	found, retVal, _ := getStructFieldValueRefByBsonPath(d.DocumentBase(), fieldNamePath)
 	if found { // if we find the field, assign the value
@@ -38,6 +40,9 @@ func (d *Base) SetField(fieldNamePath string, newValue interface{}) error {
 // GetField returns an interface to a value from the document via the bson field name path
 func (d *Base) GetField(fieldNamePath string) (interface{}, error) {
 	log.Tracef("GetField(%s)", fieldNamePath)
+	if !d.loaded.isLoaded(fieldNamePath) {
+		return nil, &mongoidError.DocumentFieldNotLoaded{FieldName: fieldNamePath}
+	}
 	// get a Value handle to the field we want
 	found, retVal, _ := getStructFieldValueRefByBsonPath(d.DocumentBase(), fieldNamePath)
 	if found { // if we find the field, return an interface to the value
@@ -96,10 +101,24 @@ func getStructFieldValueRefByBsonName(rawStructPtr interface{}, fieldName string
 	return
 }
 
-// like getStructFieldValueRefByBson but follows a bson path
+// like getStructFieldValueRefByBson but follows a dotted bson path (ie, "address.city") through embedded structs and non-nil struct pointers
 func getStructFieldValueRefByBsonPath(rawStructPtr interface{}, fieldNamePath string) (found bool, retVal reflect.Value, retField reflect.StructField) {
-	// walk each struct field
-	return getStructFieldValueRefByBsonName(rawStructPtr, fieldNamePath) // TODO FINISH ME
-	// log.Fatalf("getStructFieldValueRefByBsonPath(%s)", fieldNamePath)
-	// return
+	pathParts := strings.Split(fieldNamePath, ".")
+	found, retVal, retField = getStructFieldValueRefByBsonName(rawStructPtr, pathParts[0])
+	for _, part := range pathParts[1:] {
+		if !found {
+			return
+		}
+		if retVal.Kind() == reflect.Ptr {
+			if retVal.IsNil() {
+				return false, reflect.Value{}, reflect.StructField{}
+			}
+			retVal = retVal.Elem()
+		}
+		if retVal.Kind() != reflect.Struct {
+			return false, reflect.Value{}, reflect.StructField{}
+		}
+		found, retVal, retField = getStructFieldValueRefByBsonName(retVal.Addr().Interface(), part)
+	}
+	return
 }
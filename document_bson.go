@@ -88,6 +88,8 @@ func makeBsonDocumentDiff(oldBson, newBson BsonDocument) BsonDocument {
 // The key/value included pairs will always reflect the "new" state, according to the given args.
 // Unset keys or otherwise missing values will have the value side of their key/value pair set to 'nil', to reflect the newly unset status.
 // Consumers should interpret nil-value keys in accordance to their own particular data situations
+// Sub-documents found within both states are compared field by field, with their changes given as dotted path keys (ie, "address.city"),
+// so that only the changed fields of an embedded document are reported rather than the whole sub-document.
 func makeBsonMDiff(oldBson, newBson bson.M) bson.M {
 	changedBson := make(bson.M) // return value
 	touchedBsonKeys := make(map[string]bool)
@@ -98,6 +100,10 @@ func makeBsonMDiff(oldBson, newBson bson.M) bson.M {
 		newValue, newOk := newBson[key]
 		if newOk != true { // key/value is missing within the new state
 			changedBson[key] = nil
+		} else if oldSubBson, newSubBson, ok := bothNonNilBsonM(oldValue, newValue); ok { // sub-document in both states, so diff the fields within
+			for subKey, subValue := range makeBsonMDiff(oldSubBson, newSubBson) {
+				changedBson[key+"."+subKey] = subValue
+			}
 		} else { // key/value is still within the new, but may be changed (yet unknown)
 			wasChanged := !reflect.DeepEqual(oldValue, newValue)
 			if wasChanged {
@@ -117,10 +123,84 @@ func makeBsonMDiff(oldBson, newBson bson.M) bson.M {
 	return changedBson // otherwise hand back what was built
 }
 
-// TODO: evaluate this function -- it seems maybe unnecessary given reflect.DeepEqual functionality
-// builds a bson.A representation of pending changes, based on given inputs of oldBson & newBson
-func makeBsonADiff(oldBson, newBson bson.A) bson.A {
-	log.Panic("makeBsonADiff() - NYI")
-	changedBson := make(bson.A, 0)
-	return changedBson
+// returns both values as bson.M when both are non-nil sub-documents
+// (a nil sub-document, as stored for an empty struct, is replaced as a whole rather than diffed)
+func bothNonNilBsonM(oldValue, newValue interface{}) (oldBson, newBson bson.M, ok bool) {
+	oldBson, oldOk := oldValue.(bson.M)
+	newBson, newOk := newValue.(bson.M)
+	if !oldOk || !newOk || oldBson == nil || newBson == nil {
+		return nil, nil, false
+	}
+	return oldBson, newBson, true
+}
+
+// returns the value found at the given dotted field path (ie, "address.city") within the bson.M, and true if the path was found
+func getBsonMValueByPath(bsonM bson.M, fieldPath string) (interface{}, bool) {
+	pathParts := strings.Split(fieldPath, ".")
+	var value interface{} = bsonM
+	for _, part := range pathParts {
+		subBson, ok := value.(bson.M)
+		if !ok {
+			return nil, false
+		}
+		if value, ok = subBson[part]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// builds the atomic array update operator for the change from oldBson to newBson, returned as the operator and the value to give it for the array field:
+// $push with $each when elements were only appended, or $pull (a single value) / $pullAll (several values) when elements were only removed.
+// Since $pull and $pullAll remove every element equal to a given value, removals are only expressible when no equal elements remain in newBson.
+// Removed sub-documents are never expressible, as the database compares them with their field order, which bson.M does not preserve.
+// When the change is not expressible as a single operator, ok is false and the whole array should be replaced via $set instead.
+func makeBsonADiff(oldBson, newBson bson.A) (operator string, value interface{}, ok bool) {
+	if len(newBson) > len(oldBson) { // appended elements only
+		if !reflect.DeepEqual(append(bson.A{}, newBson[:len(oldBson)]...), append(bson.A{}, oldBson...)) { // copies, so nil and empty compare equal
+			return "", nil, false
+		}
+		return "$push", bson.M{"$each": append(bson.A{}, newBson[len(oldBson):]...)}, true
+	}
+	if len(newBson) == len(oldBson) { // same length but changed (reordered or replaced elements)
+		return "", nil, false
+	}
+
+	// removed elements only
+	removed := bson.A{}
+	for _, oldElement := range oldBson {
+		switch oldElement.(type) {
+		case bson.M, bson.D, bson.A:
+			if !bsonAContains(newBson, oldElement) {
+				return "", nil, false // removed sub-documents and arrays are not expressible
+			}
+			continue
+		}
+		if !bsonAContains(newBson, oldElement) && !bsonAContains(removed, oldElement) {
+			removed = append(removed, oldElement)
+		}
+	}
+	remaining := bson.A{}
+	for _, oldElement := range oldBson {
+		if !bsonAContains(removed, oldElement) {
+			remaining = append(remaining, oldElement)
+		}
+	}
+	if len(removed) == 0 || !reflect.DeepEqual(remaining, append(bson.A{}, newBson...)) {
+		return "", nil, false
+	}
+	if len(removed) == 1 {
+		return "$pull", removed[0], true
+	}
+	return "$pullAll", removed, true
+}
+
+// returns true if the bson.A contains an element equal to the given element
+func bsonAContains(bsonA bson.A, element interface{}) bool {
+	for _, candidate := range bsonA {
+		if reflect.DeepEqual(candidate, element) {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,99 @@
+package mongoid
+
+import (
+	"context"
+	mongoidError "mongoid/errors"
+	"mongoid/log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// BeforeDestroyer may be implemented by document types to run code before Destroy deletes the document.
+// Returning an error cancels the destroy, and the error is returned by Destroy.
+type BeforeDestroyer interface {
+	BeforeDestroy() error
+}
+
+// AfterDestroyer may be implemented by document types to run code after Destroy has deleted the document.
+// An error returned is passed along by Destroy, although the document has already been deleted.
+type AfterDestroyer interface {
+	AfterDestroy() error
+}
+
+// IsDestroyed returns true if the document has been deleted from the database via Delete or Destroy
+func (d *Base) IsDestroyed() bool {
+	log.Trace("Base.IsDestroyed()")
+	return d.destroyed
+}
+
+// Delete removes the document from the database by its _id, without running any lifecycle hooks (see Destroy).
+// Afterwards the document is no longer persisted and is marked as destroyed, so it cannot be saved again.
+// If the document was not found within the database, it is still marked as destroyed and ErrResultNotFound is returned.
+func (d *Base) Delete() error {
+	log.Debugf("%v.Delete()", d.Model().modelName)
+	if err := d.verifyDeletable("Base.Delete"); err != nil {
+		return err
+	}
+
+	collection := d.getMongoCollectionHandle()
+	ctx, ctxCancel := context.WithTimeout(context.TODO(), 5*time.Second) // todo context with arbitrary 5sec timeout
+	defer ctxCancel()
+
+	id := d.GetID()
+	selectFilter := bson.M{"_id": id}
+	log.Debugf("collection[%s].DeleteOne %v", collection.Name(), selectFilter)
+	res, err := collection.DeleteOne(ctx, selectFilter)
+	if err != nil {
+		return err
+	}
+	d.setPersisted(false) // this is no longer persisted
+	d.destroyed = true
+	if res.DeletedCount == 0 {
+		return mongoidError.ErrResultNotFound
+	}
+	return nil
+}
+
+// Destroy removes the document from the database the same way as Delete, running the lifecycle hooks of the document type
+// (see BeforeDestroyer and AfterDestroyer) before and after the deletion.
+// The hooks are not run for documents that cannot be deleted (those never saved, or already destroyed).
+func (d *Base) Destroy() error {
+	log.Debugf("%v.Destroy()", d.Model().modelName)
+	if err := d.verifyDeletable("Base.Destroy"); err != nil {
+		return err
+	}
+	if hook, ok := d.DocumentBase().(BeforeDestroyer); ok {
+		if err := hook.BeforeDestroy(); err != nil {
+			return err
+		}
+	}
+	if err := d.Delete(); err != nil {
+		return err
+	}
+	if hook, ok := d.DocumentBase().(AfterDestroyer); ok {
+		return hook.AfterDestroy()
+	}
+	return nil
+}
+
+// returns an InvalidOperation error on behalf of methodName when the document has already been destroyed, or was never saved
+func (d *Base) verifyDeletable(methodName string) error {
+	if d.IsDestroyed() {
+		return mongoidError.InvalidOperation{MethodName: methodName, Reason: "document has already been destroyed"}
+	}
+	if !d.IsPersisted() {
+		return mongoidError.InvalidOperation{MethodName: methodName, Reason: "document has not been saved"}
+	}
+	return nil
+}
+
+// DeleteByID removes the documents with the given ids from the database, without loading them (so no lifecycle hooks are run).
+// The default scope of the ModelType is not applied. Errors of the database server are returned rather than raised.
+func (model *ModelType) DeleteByID(ids ...ObjectID) (BatchResult, error) {
+	log.Debugf("%v.DeleteByID(%v)", model.GetModelName(), ids)
+	if len(ids) == 0 {
+		return BatchResult{}, nil
+	}
+	return model.Unscoped().Find(ids...).DeleteAll()
+}
@@ -0,0 +1,135 @@
+package mongoid_test
+
+import (
+	"errors"
+	"mongoid"
+	mongoidError "mongoid/errors"
+
+	gofakeit "github.com/brianvoe/gofakeit/v6"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type DestroyTestModel struct {
+	mongoid.Base
+	ID       mongoid.ObjectID `bson:"_id"`
+	Name     string
+	hooksRun []string // hooks run, in order
+}
+
+var errDestroyTestKeep = errors.New("kept")
+
+func (d *DestroyTestModel) BeforeDestroy() error {
+	d.hooksRun = append(d.hooksRun, "before")
+	if d.Name == "keep" {
+		return errDestroyTestKeep
+	}
+	return nil
+}
+
+func (d *DestroyTestModel) AfterDestroy() error {
+	d.hooksRun = append(d.hooksRun, "after")
+	return nil
+}
+
+var DestroyTestModels = mongoid.Register(&DestroyTestModel{})
+
+func createDestroyTestModel(name string) *DestroyTestModel {
+	doc := DestroyTestModels.New().(*DestroyTestModel)
+	doc.Name = name
+	Expect(doc.Save()).To(Succeed())
+	return doc
+}
+
+var _ = Describe("Document", func() {
+	Context(".Delete()", func() {
+		It("refuses documents that were never saved", func() {
+			doc := DestroyTestModels.New().(*DestroyTestModel)
+			Expect(doc.Delete()).To(BeAssignableToTypeOf(mongoidError.InvalidOperation{}))
+			Expect(doc.IsDestroyed()).To(BeFalse())
+		})
+		It("removes the record and marks the document as destroyed", func() {
+			OnlineDatabaseOnly(func() {
+				doc := createDestroyTestModel(gofakeit.Name())
+				Expect(doc.Delete()).To(Succeed())
+				Expect(doc.IsPersisted()).To(BeFalse())
+				Expect(doc.IsDestroyed()).To(BeTrue())
+				Expect(doc.hooksRun).To(BeEmpty())
+				Expect(DestroyTestModels.Where(mongoid.Q{"_id": doc.ID}).Exists()).To(BeFalse())
+			})
+		})
+		It("prevents the document from being saved again", func() {
+			OnlineDatabaseOnly(func() {
+				doc := createDestroyTestModel(gofakeit.Name())
+				Expect(doc.Delete()).To(Succeed())
+				Expect(doc.Save()).To(BeAssignableToTypeOf(mongoidError.InvalidOperation{}))
+				Expect(doc.Delete()).To(BeAssignableToTypeOf(mongoidError.InvalidOperation{}))
+				Expect(DestroyTestModels.Where(mongoid.Q{"_id": doc.ID}).Exists()).To(BeFalse())
+			})
+		})
+		It("returns ResultNotFound when the record was already removed", func() {
+			OnlineDatabaseOnly(func() {
+				doc := createDestroyTestModel(gofakeit.Name())
+				_, err := DestroyTestModels.DeleteByID(doc.ID)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(doc.Delete()).To(Equal(mongoidError.ErrResultNotFound))
+				Expect(doc.IsDestroyed()).To(BeTrue())
+			})
+		})
+	})
+
+	Context(".Destroy()", func() {
+		It("runs the lifecycle hooks around the delete", func() {
+			OnlineDatabaseOnly(func() {
+				doc := createDestroyTestModel(gofakeit.Name())
+				Expect(doc.Destroy()).To(Succeed())
+				Expect(doc.hooksRun).To(Equal([]string{"before", "after"}))
+				Expect(doc.IsDestroyed()).To(BeTrue())
+			})
+		})
+		It("is cancelled by an error from BeforeDestroy", func() {
+			OnlineDatabaseOnly(func() {
+				doc := createDestroyTestModel("keep")
+				Expect(doc.Destroy()).To(MatchError(errDestroyTestKeep))
+				Expect(doc.hooksRun).To(Equal([]string{"before"}))
+				Expect(doc.IsDestroyed()).To(BeFalse())
+				Expect(doc.IsPersisted()).To(BeTrue())
+				Expect(DestroyTestModels.Where(mongoid.Q{"_id": doc.ID}).Exists()).To(BeTrue())
+			})
+		})
+		It("runs no hooks for documents that cannot be deleted", func() {
+			doc := DestroyTestModels.New().(*DestroyTestModel)
+			Expect(doc.Destroy()).To(BeAssignableToTypeOf(mongoidError.InvalidOperation{}))
+			Expect(doc.hooksRun).To(BeEmpty())
+		})
+		It("runs no hooks for documents already destroyed", func() {
+			OnlineDatabaseOnly(func() {
+				doc := createDestroyTestModel(gofakeit.Name())
+				Expect(doc.Destroy()).To(Succeed())
+				Expect(doc.Destroy()).To(BeAssignableToTypeOf(mongoidError.InvalidOperation{}))
+				Expect(doc.hooksRun).To(Equal([]string{"before", "after"}))
+			})
+		})
+	})
+})
+
+var _ = Describe("ModelType", func() {
+	Context(".DeleteByID()", func() {
+		It("removes the records with the given ids", func() {
+			OnlineDatabaseOnly(func() {
+				first := createDestroyTestModel(gofakeit.Name())
+				second := createDestroyTestModel(gofakeit.Name())
+				kept := createDestroyTestModel(gofakeit.Name())
+				result, err := DestroyTestModels.DeleteByID(first.ID, second.ID)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.DeletedCount).To(Equal(int64(2)))
+				Expect(DestroyTestModels.Where(mongoid.Q{"_id": kept.ID}).Exists()).To(BeTrue())
+			})
+		})
+		It("does nothing without ids", func() {
+			result, err := DestroyTestModels.DeleteByID()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.DeletedCount).To(Equal(int64(0)))
+		})
+	})
+})
@@ -12,6 +12,7 @@ import (
 // "time"
 
 var reflectTypeObjectID = reflect.TypeOf(ZeroObjectID)
+var reflectTypeBase = reflect.TypeOf(Base{})
 
 // Apply matching values to the given struct (passed by pointer) from the given bsonM.
 // If a value for a field is not found within the given bsonM, it will be skipped without error.
@@ -49,6 +50,23 @@ func structValuesFromBsonM(rawStructPtr interface{}, bsonM bson.M) (updated bool
 	return
 }
 
+// sets every exported field of the given struct (passed by pointer) to its zero value, except for the embedded Base
+// and fields omitted via `bson:"-"`, which are never stored and so could never be restored
+func resetStructValues(rawStructPtr interface{}) {
+	structValue := reflect.Indirect(reflect.ValueOf(rawStructPtr))
+	structType := structValue.Type()
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if field.PkgPath != "" || field.Type == reflectTypeBase {
+			continue
+		}
+		if tagFieldName, _, _, _ := getBsonStructTagOpts(field); tagFieldName == "-" {
+			continue
+		}
+		structValue.Field(i).Set(reflect.Zero(field.Type))
+	}
+}
+
 // Retrieves the value for a struct field from the given bsonM, following the direction of struct field tags if present.
 // Will return found=true when a matching value was available within the given bsonM, otherwise found=false.
 // This will not assign a matching value to the struct field -- you will need to do that yourself.
@@ -130,6 +148,18 @@ func structFieldValueFromBsonM(field reflect.StructField, bsonM bson.M) (retValu
 		return retValue, false
 	}
 
+	// GeoJSON types are read from their GeoJSON form, rather than as a struct of their fields
+	if reflect.PtrTo(fieldType).Implements(reflectTypeGeoJSONField) {
+		var ok bool
+		if retValue, ok = geoJSONValueFromBson(fieldType, bsonMfieldValue); !ok {
+			log.Panicf("invalid GeoJSON value for %s field '%s': %v", fieldType, fieldName, bsonMfieldValue)
+		}
+		if fieldTypeKind == reflect.Ptr {
+			retValue = retValue.Addr()
+		}
+		return retValue, true
+	}
+
 	// TODO should look into handling custom FromBSON marshallables here...
 	if util.IsIfaceBsonMarshalSafe(bsonMfieldValue) {
 		// log.Fatal("util.IsBsonMarshalSafe() is not a real thing -- that was more than 10 lies")
@@ -186,7 +216,19 @@ func sliceValueFromUnknownAry(sliceType reflect.Type, unknownAry interface{}) (r
 	if sliceElemTypeKind == reflect.Ptr { // pointer-based types
 		// assign each slice index by ptr
 		sliceElemBaseType := sliceElemType.Elem()
-		if sliceElemBaseType.Kind() == reflect.Struct {
+		if reflect.PtrTo(sliceElemBaseType).Implements(reflectTypeGeoJSONField) {
+			// ptrs to GeoJSON types
+			for i := 0; i < lenBsonA; i++ {
+				if !reflect.ValueOf(unknownAry).Index(i).IsNil() { // only process non-nil values
+					bsonIndexValue := reflect.ValueOf(unknownAry).Index(i).Interface()
+					indexValue, ok := geoJSONValueFromBson(sliceElemBaseType, bsonIndexValue)
+					if !ok {
+						log.Panicf("sliceValueFromBsonA - value at index %d must be valid GeoJSON when slice kind is %s: %v", i, sliceElemType, bsonIndexValue)
+					}
+					sliceValue.Index(i).Set(indexValue.Addr())
+				}
+			}
+		} else if sliceElemBaseType.Kind() == reflect.Struct {
 			// ptrs to custom struct type
 			for i := 0; i < lenBsonA; i++ {
 				// if bsonA[i] != nil { // only process non-nil values
@@ -222,7 +264,17 @@ func sliceValueFromUnknownAry(sliceType reflect.Type, unknownAry interface{}) (r
 			sliceIndexValue.Set(bsonIndexValue)
 		}
 	} else { // non-pointer-based types (concrete types)
-		if sliceElemTypeKind == reflect.Struct {
+		if reflect.PtrTo(sliceElemType).Implements(reflectTypeGeoJSONField) {
+			// GeoJSON types
+			for i := 0; i < lenBsonA; i++ {
+				bsonIndexValue := reflect.ValueOf(unknownAry).Index(i).Interface()
+				indexValue, ok := geoJSONValueFromBson(sliceElemType, bsonIndexValue)
+				if !ok {
+					log.Panicf("sliceValueFromBsonA - value at index %d must be valid GeoJSON when slice kind is %s: %v", i, sliceElemType, bsonIndexValue)
+				}
+				sliceValue.Index(i).Set(indexValue)
+			}
+		} else if sliceElemTypeKind == reflect.Struct {
 			// custom struct type
 			for i := 0; i < lenBsonA; i++ {
 				bsonM, ok := reflect.ValueOf(unknownAry).Index(i).Interface().(bson.M)
@@ -247,3 +299,14 @@ func sliceValueFromUnknownAry(sliceType reflect.Type, unknownAry interface{}) (r
 
 	return sliceValue
 }
+
+// reads a GeoJSON value into a new value of the given GeoJSON type, returning false if the value is not valid GeoJSON of that type.
+// A null value reads as the zero value, as zero shapes are stored as null (see geometryToBson).
+func geoJSONValueFromBson(geoType reflect.Type, bsonValue interface{}) (reflect.Value, bool) {
+	valuePtrValue := reflect.New(geoType)
+	if bsonValue == nil {
+		return valuePtrValue.Elem(), true
+	}
+	geoJSON, _ := bsonValue.(bson.M)
+	return valuePtrValue.Elem(), valuePtrValue.Interface().(geoJSONField).fromGeoJSON(geoJSON)
+}
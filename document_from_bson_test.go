@@ -153,3 +153,23 @@ var _ = Describe("structValuesFromBsonM()", func() {
 	}) // Context("updating a struct field value", func() {
 
 })
+
+var _ = Describe("resetStructValues", func() {
+	type resetTestDoc struct {
+		Base
+		Name    string
+		Tags    []string
+		Scratch string `bson:"-"`
+		private int
+	}
+	It("zeroes exported fields, leaving the Base, unexported fields and bson:\"-\" fields alone", func() {
+		doc := &resetTestDoc{Name: "bob", Tags: []string{"a"}, Scratch: "transient", private: 3}
+		doc.Base.persisted = true
+		resetStructValues(doc)
+		Expect(doc.Name).To(BeEmpty())
+		Expect(doc.Tags).To(BeNil())
+		Expect(doc.Scratch).To(Equal("transient"))
+		Expect(doc.private).To(Equal(3))
+		Expect(doc.Base.persisted).To(BeTrue())
+	})
+})
@@ -0,0 +1,128 @@
+package mongoid
+
+import (
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// loadedFields describes which fields of a document were loaded from the database, according to the projection of the query that produced it.
+// A nil *loadedFields indicates that the entire document was loaded.
+type loadedFields struct {
+	inclusive bool            // true if only the listed paths were loaded, false if everything except the listed paths were loaded
+	paths     map[string]bool // bson field paths named by the projection
+}
+
+// builds a loadedFields from a driver-ready projection document (see Criteria.getProjectionBsonD), following the MongoDB projection rules:
+// any included field makes the projection inclusive, and _id is always loaded.
+// $meta projections (ie, the text search score) add a field rather than select fields, so they are ignored.
+func makeLoadedFields(projection bson.D) *loadedFields {
+	selecting := bson.D{}
+	for _, element := range projection {
+		if !isMetaProjectionValue(element.Value) {
+			selecting = append(selecting, element)
+		}
+	}
+	projection = selecting
+	if len(projection) == 0 {
+		return nil
+	}
+	inclusive := false
+	for _, element := range projection {
+		if projectionValueIncludes(element.Value) {
+			inclusive = true
+		}
+	}
+	loaded := &loadedFields{
+		inclusive: inclusive,
+		paths:     make(map[string]bool),
+	}
+	if inclusive {
+		loaded.paths["_id"] = true
+	}
+	for _, element := range projection {
+		if projectionValueIncludes(element.Value) == inclusive {
+			loaded.paths[element.Key] = true
+		}
+	}
+	return loaded
+}
+
+// returns true if the given projection value includes the field
+// anything other than a zero or false value counts as an include, same as the MongoDB server (ie, $slice projections)
+func projectionValueIncludes(value interface{}) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case int:
+		return v != 0
+	case int32:
+		return v != 0
+	case int64:
+		return v != 0
+	case float64:
+		return v != 0
+	}
+	return true
+}
+
+// returns true if the given projection value is a $meta expression (ie, {$meta: "textScore"})
+func isMetaProjectionValue(value interface{}) bool {
+	switch v := value.(type) {
+	case bson.D:
+		return len(v) == 1 && v[0].Key == "$meta"
+	case bson.M:
+		_, ok := v["$meta"]
+		return ok
+	case Query:
+		_, ok := v["$meta"]
+		return ok
+	}
+	return false
+}
+
+// isLoaded returns true if the value at the given bson field path was fully loaded from the database.
+// Parent fields of a partially loaded embedded document are not considered loaded, since saving them would overwrite the unloaded values.
+func (loaded *loadedFields) isLoaded(fieldPath string) bool {
+	if loaded == nil {
+		return true
+	}
+	named := false   // fieldPath (or a parent of it) is named by the projection
+	partial := false // a child of fieldPath is named by the projection
+	for path := range loaded.paths {
+		if path == fieldPath || strings.HasPrefix(fieldPath, path+".") {
+			named = true
+		} else if strings.HasPrefix(path, fieldPath+".") {
+			partial = true
+		}
+	}
+	if loaded.inclusive {
+		return named
+	}
+	return !named && !partial
+}
+
+// returns the keys of the given bson.M for fields that were not loaded, in sorted order
+func (loaded *loadedFields) unloadedKeys(bsonM bson.M) []string {
+	keys := []string{}
+	if loaded == nil {
+		return keys
+	}
+	for key := range bsonM {
+		if !loaded.isLoaded(key) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// sets the loaded fields of the document.
+// Change tracking restarts from the current values, so the zero values of unloaded fields only count as changes once those fields are assigned.
+func (d *Base) setLoadedFields(loaded *loadedFields) {
+	d.loaded = loaded
+	if loaded != nil {
+		d.refreshPreviousValueBSON()
+	}
+}
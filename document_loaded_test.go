@@ -0,0 +1,123 @@
+package mongoid
+
+import (
+	mongoidError "mongoid/errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("loadedFields", func() {
+	It("loads everything without a projection", func() {
+		loaded := makeLoadedFields(bson.D{})
+		Expect(loaded).To(BeNil())
+		Expect(loaded.isLoaded("anything")).To(BeTrue())
+	})
+
+	Context("inclusive projection", func() {
+		loaded := makeLoadedFields(bson.D{{Key: "name", Value: 1}, {Key: "address.city", Value: int32(1)}})
+		It("loads the included fields and _id", func() {
+			Expect(loaded.isLoaded("name")).To(BeTrue())
+			Expect(loaded.isLoaded("_id")).To(BeTrue())
+			Expect(loaded.isLoaded("address.city")).To(BeTrue())
+		})
+		It("does not load other fields", func() {
+			Expect(loaded.isLoaded("age")).To(BeFalse())
+			Expect(loaded.isLoaded("address.zip")).To(BeFalse())
+		})
+		It("does not consider a partially loaded parent as loaded", func() {
+			Expect(loaded.isLoaded("address")).To(BeFalse())
+		})
+	})
+
+	Context("exclusive projection", func() {
+		loaded := makeLoadedFields(bson.D{{Key: "name", Value: 0}, {Key: "address.city", Value: false}})
+		It("does not load the excluded fields", func() {
+			Expect(loaded.isLoaded("name")).To(BeFalse())
+			Expect(loaded.isLoaded("address.city")).To(BeFalse())
+		})
+		It("loads other fields", func() {
+			Expect(loaded.isLoaded("_id")).To(BeTrue())
+			Expect(loaded.isLoaded("age")).To(BeTrue())
+			Expect(loaded.isLoaded("address.zip")).To(BeTrue())
+		})
+		It("does not consider a partially loaded parent as loaded", func() {
+			Expect(loaded.isLoaded("address")).To(BeFalse())
+		})
+	})
+
+	It("lists the unloaded keys of a bson.M", func() {
+		loaded := makeLoadedFields(bson.D{{Key: "name", Value: 1}})
+		Expect(loaded.unloadedKeys(bson.M{"name": "a", "zip": 1, "age": 1})).To(Equal([]string{"age", "zip"}))
+		Expect(loaded.unloadedKeys(bson.M{"name": "a"})).To(BeEmpty())
+	})
+
+	It("ignores $meta projections", func() {
+		meta := bson.D{{Key: "$meta", Value: "textScore"}}
+		Expect(makeLoadedFields(bson.D{{Key: "score", Value: meta}})).To(BeNil())
+		loaded := makeLoadedFields(bson.D{{Key: "score", Value: meta}, {Key: "age", Value: 0}})
+		Expect(loaded.isLoaded("name")).To(BeTrue())
+		Expect(loaded.isLoaded("age")).To(BeFalse())
+	})
+})
+
+var _ = Describe("Criteria Projection", func() {
+	type projectionTestDoc struct {
+		Base
+		FirstName string
+		Age       int `bson:"years"`
+	}
+	model := &ModelType{rootTypeRef: &projectionTestDoc{}}
+
+	It("builds an inclusive projection with bson field names", func() {
+		criteria := model.Only("FirstName", "Age").(*criteriaStruct)
+		Expect(criteria.getFindOptions().Projection).To(Equal(bson.D{
+			{Key: "first_name", Value: int32(1)},
+			{Key: "years", Value: int32(1)},
+		}))
+	})
+	It("builds an exclusive projection with bson field names", func() {
+		criteria := model.Where(Q{}).Without("FirstName").(*criteriaStruct)
+		Expect(criteria.getProjectionBsonD()).To(Equal(bson.D{{Key: "first_name", Value: int32(0)}}))
+	})
+	It("drops exclusions from an inclusive projection", func() {
+		criteria := model.Only("FirstName").Without("Age").(*criteriaStruct)
+		Expect(criteria.getProjectionBsonD()).To(Equal(bson.D{{Key: "first_name", Value: int32(1)}}))
+		criteria = model.Only("FirstName", "Age").Without("Age").(*criteriaStruct)
+		Expect(criteria.getProjectionBsonD()).To(Equal(bson.D{{Key: "first_name", Value: int32(1)}}))
+		criteria = model.Without("Age").Only("FirstName").(*criteriaStruct)
+		Expect(criteria.getProjectionBsonD()).To(Equal(bson.D{{Key: "first_name", Value: int32(1)}}))
+	})
+	It("never excludes _id", func() {
+		Expect(model.Without("_id").(*criteriaStruct).getProjectionBsonD()).To(Equal(bson.D{}))
+		Expect(model.Without("_id", "FirstName").(*criteriaStruct).getProjectionBsonD()).To(Equal(bson.D{{Key: "first_name", Value: int32(0)}}))
+		loaded := makeLoadedFields(model.Only("FirstName").Without("_id").(*criteriaStruct).getProjectionBsonD())
+		Expect(loaded.isLoaded("_id")).To(BeTrue())
+	})
+	It("does not report the zero values of unloaded fields as changes", func() {
+		doc := makeDocument(&ModelType{rootTypeRef: &projectionTestDoc{}}, bson.M{"first_name": "bob"})
+		doc.setLoadedFields(makeLoadedFields(model.Only("FirstName").(*criteriaStruct).getProjectionBsonD()))
+		Expect(doc.Changes()).To(BeEmpty())
+		doc.(*projectionTestDoc).FirstName = "alice"
+		Expect(doc.Changes()).To(Equal(BsonDocument{"first_name": "alice"}))
+	})
+	It("refuses to write unloaded fields", func() {
+		type projectionSaveTestDoc struct {
+			Base      `mongoid:"collection:projection_save_test_docs"`
+			FirstName string
+			Age       int `bson:"years"`
+		}
+		saveModel := Register(&projectionSaveTestDoc{})
+		doc := makeDocument(saveModel, bson.M{"first_name": "bob"})
+		doc.setLoadedFields(makeLoadedFields(saveModel.Only("FirstName").(*criteriaStruct).getProjectionBsonD()))
+		doc.setPersisted(true)
+		err := doc.SetField("years", 5)
+		Expect(mongoidError.IsDocumentFieldNotLoaded(err)).To(BeTrue())
+		doc.(*projectionSaveTestDoc).Age = 5
+		err = doc.Save()
+		Expect(mongoidError.IsDocumentFieldNotLoaded(err)).To(BeTrue())
+		Expect(err.(*mongoidError.DocumentFieldNotLoaded).FieldName).To(Equal("years"))
+	})
+})
@@ -39,6 +39,8 @@ func (d *Base) IsChanged() bool {
 // Entries that are unchanged are excluded from the output BsonDocument.
 // New or changed values will have a key/value pair that reflects the newly set entry value.
 // Unset or missing values will have an key/value pair with 'nil' as the value side, to reflect the unset status.
+// Changes within embedded documents are keyed by their dotted path (ie, "address.city") rather than replacing the whole embedded document.
+// Fields that were not loaded from the datastore (see Criteria.Only() and Criteria.Without()) are only included once assigned, which makes Save() fail.
 func (d *Base) Changes() BsonDocument {
 	log.Trace("Base.Changes()")
 	currentBson := d.ToBson()
@@ -47,7 +49,8 @@ func (d *Base) Changes() BsonDocument {
 	return diffBson
 }
 
-// Was provides the previous field value and indicates if a change has occurred
+// Was provides the previous field value and indicates if a change has occurred.
+// Fields of embedded documents may be given by their dotted path (ie, "address.city").
 func (d *Base) Was(fieldPath string) (interface{}, bool) {
 	value, err := d.GetField(fieldPath)
 	if err != nil {
@@ -66,8 +69,15 @@ func (d *Base) Was(fieldPath string) (interface{}, bool) {
 
 // Save will store the changed attributes to the database atomically, or insert the document if flagged as a new record via Model#new_record?
 // Can bypass validations if wanted.
+// Returns a DocumentFieldNotLoaded error without saving anything if a field that was not loaded from the datastore has been changed.
 func (d *Base) Save() error {
 	log.Debugf("%v.Save()", d.Model().modelName)
+	if d.IsDestroyed() {
+		return mongoidError.InvalidOperation{MethodName: "Base.Save", Reason: "document has been destroyed"}
+	}
+	if unloaded := d.loaded.unloadedKeys(d.Changes()); len(unloaded) > 0 {
+		return &mongoidError.DocumentFieldNotLoaded{FieldName: unloaded[0]}
+	}
 
 	// if already persisted, this is an update, otherwise it's a new insert
 	if d.IsPersisted() {
@@ -86,6 +96,9 @@ func (d *Base) saveByUpdate() error {
 
 	selectFilter := bson.M{"_id": d.GetID()}
 	updateBson := d.ToUpdateBson()
+	if len(updateBson) == 0 {
+		return nil // nothing has changed, so there is nothing to write
+	}
 	log.Debugf("collection[%s].UpdateOne %v %v", collection.Name(), selectFilter, updateBson)
 	_, err := collection.UpdateOne(ctx, selectFilter, updateBson)
 	if err != nil {
@@ -139,6 +152,42 @@ func (d *Base) saveByInsert() error {
 	return nil
 }
 
+// Reload reads the document again from the database by its _id, replacing every field value of the document with the stored values
+// and resetting change tracking (so any unsaved changes are discarded).
+// Returns ErrResultNotFound if the record no longer exists, in which case the document is left unaltered.
+func (d *Base) Reload() error {
+	log.Debugf("%v.Reload()", d.Model().modelName)
+	id := d.GetID()
+	if d.IsDestroyed() {
+		return mongoidError.ErrResultNotFound
+	}
+	if !d.IsPersisted() {
+		return mongoidError.InvalidOperation{MethodName: "Base.Reload", Reason: "document has not been saved"}
+	}
+
+	collection := d.getMongoCollectionHandle()
+	ctx, ctxCancel := context.WithTimeout(context.TODO(), 5*time.Second) // todo context with arbitrary 5sec timeout
+	defer ctxCancel()
+
+	selectFilter := bson.M{"_id": id}
+	log.Debugf("collection[%s].FindOne %v", collection.Name(), selectFilter)
+	var bsonM bson.M
+	if err := collection.FindOne(ctx, selectFilter).Decode(&bsonM); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return mongoidError.ErrResultNotFound
+		}
+		return err
+	}
+
+	// fields missing from the record must not keep their current values, so start from zero values the same as a newly loaded document
+	resetStructValues(d.rootTypeRef)
+	structValuesFromBsonM(d.rootTypeRef, bsonM)
+	d.setPreviousValueBSON(bsonM)
+	d.setLoadedFields(nil) // every field was loaded
+	d.textScore = nil
+	return nil
+}
+
 // returns a handle to the mongo driver collection for this document instance
 func (d *Base) getMongoCollectionHandle() *mongo.Collection {
 	dModel := d.Model()
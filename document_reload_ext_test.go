@@ -0,0 +1,45 @@
+package mongoid_test
+
+import (
+	"mongoid"
+	mongoidError "mongoid/errors"
+
+	gofakeit "github.com/brianvoe/gofakeit/v6"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Document", func() {
+	Context(".Reload()", func() {
+		It("refuses documents that were never saved", func() {
+			doc := CriteriaTestModels.New().(*CriteriaTestModel)
+			Expect(doc.Reload()).To(BeAssignableToTypeOf(mongoidError.InvalidOperation{}))
+		})
+		It("reads the values changed by others, discarding unsaved changes", func() {
+			OnlineDatabaseOnly(func() {
+				doc := createCriteriaTestModel("original", gofakeit.UUID(), 1)
+				_, err := CriteriaTestModels.Where(mongoid.Q{"_id": doc.ID}).UpdateAll(mongoid.Update{}.Set("Name", "elsewhere").Unset("Group"))
+				Expect(err).ToNot(HaveOccurred())
+				doc.Number = 5
+				Expect(doc.IsChanged()).To(BeTrue())
+
+				Expect(doc.Reload()).To(Succeed())
+				Expect(doc.Name).To(Equal("elsewhere"))
+				Expect(doc.Group).To(BeEmpty())
+				Expect(doc.Number).To(Equal(1))
+				Expect(doc.IsChanged()).To(BeFalse())
+				Expect(doc.IsPersisted()).To(BeTrue())
+			})
+		})
+		It("returns ResultNotFound once the record has been deleted", func() {
+			OnlineDatabaseOnly(func() {
+				doc := createCriteriaTestModel("deleted", gofakeit.UUID(), 1)
+				_, err := CriteriaTestModels.DeleteByID(doc.ID)
+				Expect(err).ToNot(HaveOccurred())
+				err = doc.Reload()
+				Expect(err).To(Equal(mongoidError.ErrResultNotFound))
+				Expect(doc.Name).To(Equal("deleted"))
+			})
+		})
+	})
+})
@@ -5,13 +5,13 @@ import (
 	"mongoid/util"
 
 	"reflect"
+	"time"
 
 	"github.com/iancoleman/strcase"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/bsoncodec"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	// "strings"
-	// "time"
 )
 
 // ToBson converts the document model object into a BsonDocument.
@@ -22,16 +22,52 @@ func (d *Base) ToBson() BsonDocument {
 	return bsonOut
 }
 
-// ToUpdateBson converts the document model object into a BsonDocument
+// ToUpdateBson converts the changes of the document model object (see Changes) into an update BsonDocument.
+// Changed fields are given with $set, while fields that are no longer stored at all (such as zeroed omitempty fields) are given with $unset.
+// Changes within embedded documents are given by their dotted path (ie, "address.city"), leaving the other fields of the embedded document untouched.
+// Arrays that only had elements appended or removed are given with $push or $pull/$pullAll rather than replacing the whole array (see makeBsonADiff).
+// When nothing has changed, the BsonDocument is empty.
 func (d *Base) ToUpdateBson() BsonDocument {
 	log.Trace("Base.ToUpdateBson()")
-	updateBson := bson.M{
-		"$set": d.Changes(),
+	currentBson := d.ToBson()
+	updateBson := bson.M{}
+	setBson := bson.M{}
+	unsetBson := bson.M{}
+	for key, value := range d.Changes() {
+		if _, present := getBsonMValueByPath(currentBson, key); present {
+			if operator, operatorValue, ok := d.arrayUpdateOperator(key, value); ok {
+				if _, found := updateBson[operator]; !found {
+					updateBson[operator] = bson.M{}
+				}
+				updateBson[operator].(bson.M)[key] = operatorValue
+				continue
+			}
+			setBson[key] = value // includes fields that are now null (ie, nil pointers)
+		} else {
+			unsetBson[key] = "" // fields that are no longer stored at all (ie, zeroed omitempty fields) are removed rather than stored as null
+		}
+	}
+	if len(setBson) > 0 {
+		updateBson["$set"] = setBson
+	}
+	if len(unsetBson) > 0 {
+		updateBson["$unset"] = unsetBson
 	}
-	// TODO - add an $unset operator to appropriately remove unset fields (instead of just setting them to null, like it currently does)
 	return updateBson
 }
 
+// returns the atomic array update operator for the changed value of the field at fieldPath, when both it and its previous value are arrays
+// and the change is expressible by a single operator (see makeBsonADiff)
+func (d *Base) arrayUpdateOperator(fieldPath string, value interface{}) (operator string, operatorValue interface{}, ok bool) {
+	newBsonA, newOk := value.(bson.A)
+	previousValue, _ := getBsonMValueByPath(d.previousValue, fieldPath)
+	oldBsonA, oldOk := previousValue.(bson.A)
+	if !newOk || !oldOk {
+		return "", nil, false
+	}
+	return makeBsonADiff(oldBsonA, newBsonA)
+}
+
 func structToBsonM(rawStructPtr interface{}) bson.M {
 	// log.Trace("structToBsonM(<detecting>)")
 	retMap := make(bson.M)
@@ -113,6 +149,11 @@ func structFieldToBsonM(field reflect.StructField, fieldValue reflect.Value) bso
 	fieldType := field.Type
 	fieldTypeKind := fieldType.Kind()
 
+	// empty values of omitempty fields have no entry (pointers are only empty when nil, which is handled below)
+	if tagOmitempty && fieldTypeKind != reflect.Ptr && isEmptyFieldValue(fieldValue) {
+		return bson.M{}
+	}
+
 	// if ptr and value is nil, this is really easy to solve
 	if fieldTypeKind == reflect.Ptr {
 		if fieldValue.IsNil() {
@@ -132,6 +173,15 @@ func structFieldToBsonM(field reflect.StructField, fieldValue reflect.Value) bso
 		}
 	}
 
+	// GeoJSON types are stored in their GeoJSON form, rather than as a struct of their fields
+	if geometry, ok := fieldValue.Interface().(GeoGeometry); ok {
+		geoJSON := geometryToBson(geometry, fieldTypeKind == reflect.Ptr)
+		if geoJSON == nil && tagOmitempty {
+			return bson.M{}
+		}
+		return bson.M{fieldName: geoJSON}
+	}
+
 	// TODO should look into handling custom ToBSON marshallables here...
 	// if util.IsIfaceBsonMarshalSafe(fieldValue.Interface()) {
 	// 	log.Fatal("util.IsBsonMarshalSafe() is not a real thing -- that was more than 10 lies")
@@ -197,6 +247,77 @@ func structFieldToBsonM(field reflect.StructField, fieldValue reflect.Value) bso
 	}
 }
 
+// converts the given value into the form stored for a struct field of fieldType (see structFieldToBsonM), so that values given
+// outside of a document (ie, to update operators) are stored the same way as saving a document would store them.
+// Only values of fieldType (or its element type, for pointer fields) are converted; any other value is returned unaltered.
+func marshalValueForFieldType(fieldType reflect.Type, value interface{}) interface{} {
+	if fieldType == nil || value == nil {
+		return value
+	}
+	valueType := reflect.TypeOf(value)
+	if valueType != fieldType && !(fieldType.Kind() == reflect.Ptr && valueType == fieldType.Elem()) {
+		return value
+	}
+	field := reflect.StructField{Name: "Value", Type: valueType}
+	bsonM := structFieldToBsonM(field, reflect.ValueOf(value))
+	return bsonM["value"]
+}
+
+// returns true if values of the given field type are stored as numbers, such that $inc and $mul can be applied to them (and $sum and $avg calculated over them).
+// Some numeric types are stored as strings to preserve their full value (ie, uint64 and complex numbers, see util.MarshalToDB).
+func isStoredAsNumber(fieldType reflect.Type) bool {
+	for fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+	switch fieldType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// returns true if the given value is considered empty for omitempty fields, following the same rules as the bson encoding of the driver:
+// zero numbers, false, empty strings, empty slices and maps, nil interfaces, and zero time.Time values. Other structs are never empty.
+func isEmptyFieldValue(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return value.Len() == 0
+	case reflect.Bool:
+		return !value.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return value.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return value.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return value.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return value.IsNil()
+	case reflect.Struct:
+		if t, ok := value.Interface().(time.Time); ok {
+			return t.IsZero()
+		}
+	}
+	return false
+}
+
+// returns the GeoJSON form of the given geometry, or nil for shapes that have no valid GeoJSON form (a polygon without rings).
+// A zero GeoPoint is also nil unless it was given explicitly through a pointer, so that unset point fields are not stored as the point (0,0).
+func geometryToBson(geometry GeoGeometry, explicit bool) interface{} {
+	switch shape := geometry.(type) {
+	case GeoPoint:
+		if !explicit && shape == (GeoPoint{}) {
+			return nil
+		}
+	case GeoPolygon:
+		if len(shape.Rings) == 0 {
+			return nil
+		}
+	}
+	return geometry.toGeoJSON()
+}
+
 // accepts a reflect.Value of an indexable (slice or array) and returns bson.A
 func indexableValueToBsonA(indexableValue reflect.Value) bson.A {
 	// log.Trace("indexableValueToBsonA")
@@ -207,7 +328,9 @@ func indexableValueToBsonA(indexableValue reflect.Value) bson.A {
 		if indexableValueAtIndex.Kind() == reflect.Ptr {
 			if !indexableValueAtIndex.IsNil() { // only store the non-nil values for pointers
 				indirectValueAtIndex := reflect.Indirect(indexableValueAtIndex)
-				if indirectValueAtIndex.Kind() == reflect.Struct {
+				if geometry, ok := indirectValueAtIndex.Interface().(GeoGeometry); ok {
+					retBsonA[i] = geometryToBson(geometry, true)
+				} else if indirectValueAtIndex.Kind() == reflect.Struct {
 					retBsonA[i] = structToBsonM(indirectValueAtIndex.Interface())
 				} else {
 					retBsonA[i] = indirectValueAtIndex.Interface()
@@ -215,7 +338,9 @@ func indexableValueToBsonA(indexableValue reflect.Value) bson.A {
 			}
 		} else {
 			indirectValueAtIndex := reflect.Indirect(indexableValueAtIndex)
-			if indirectValueAtIndex.Kind() == reflect.Struct {
+			if geometry, ok := indirectValueAtIndex.Interface().(GeoGeometry); ok {
+				retBsonA[i] = geometryToBson(geometry, false)
+			} else if indirectValueAtIndex.Kind() == reflect.Struct {
 				retBsonA[i] = structToBsonM(indirectValueAtIndex.Interface())
 			} else {
 				retBsonA[i] = indirectValueAtIndex.Interface()
@@ -0,0 +1,119 @@
+package mongoid_test
+
+import (
+	"mongoid"
+
+	gofakeit "github.com/brianvoe/gofakeit/v6"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type UpdateBsonTestDocument struct {
+	mongoid.Base
+	ID       mongoid.ObjectID `bson:"_id"`
+	Name     string
+	Nickname string  `bson:",omitempty"`
+	Note     *string // stored as null when nil
+	Address  UpdateBsonTestAddress
+	Tags     []string
+}
+
+type UpdateBsonTestAddress struct {
+	City string
+	Zip  string `bson:",omitempty"`
+}
+
+var UpdateBsonTestDocuments = mongoid.Register(&UpdateBsonTestDocument{Name: "name", Nickname: "nick", Address: UpdateBsonTestAddress{City: "city", Zip: "zip"}, Tags: []string{"a", "b", "c"}})
+
+var _ = Describe("Document", func() {
+	Context(".ToUpdateBson()", func() {
+		It("is empty without changes", func() {
+			doc := UpdateBsonTestDocuments.New().(*UpdateBsonTestDocument)
+			Expect(doc.ToUpdateBson()).To(BeEmpty())
+		})
+		It("sets changed fields, including those that are now null", func() {
+			doc := UpdateBsonTestDocuments.New().(*UpdateBsonTestDocument)
+			note := "note"
+			doc.Note = &note
+			Expect(doc.ToUpdateBson()).To(Equal(bson.M{"$set": bson.M{"note": "note"}}))
+		})
+		It("unsets zeroed omitempty fields, rather than storing null", func() {
+			doc := UpdateBsonTestDocuments.New().(*UpdateBsonTestDocument)
+			doc.Name = "changed"
+			doc.Nickname = ""
+			Expect(doc.ToUpdateBson()).To(Equal(bson.M{
+				"$set":   bson.M{"name": "changed"},
+				"$unset": bson.M{"nickname": ""},
+			}))
+		})
+		It("sets and unsets changed fields of embedded documents by their dotted path", func() {
+			doc := UpdateBsonTestDocuments.New().(*UpdateBsonTestDocument)
+			doc.Address.City = "changed"
+			doc.Address.Zip = ""
+			Expect(doc.Changes()).To(Equal(mongoid.BsonDocument{"address.city": "changed", "address.zip": nil}))
+			Expect(doc.ToUpdateBson()).To(Equal(bson.M{
+				"$set":   bson.M{"address.city": "changed"},
+				"$unset": bson.M{"address.zip": ""},
+			}))
+			wasValue, changed := doc.Was("address.city")
+			Expect(changed).To(BeTrue())
+			Expect(wasValue).To(Equal("city"))
+		})
+		It("leaves the other fields of embedded documents untouched when saved", func() {
+			OnlineDatabaseOnly(func() {
+				doc := UpdateBsonTestDocuments.New().(*UpdateBsonTestDocument)
+				Expect(doc.Save()).To(Succeed())
+				_, err := UpdateBsonTestDocuments.Where(mongoid.Q{"_id": doc.ID}).UpdateAll(mongoid.Update{}.Set("address.zip", "elsewhere"))
+				Expect(err).ToNot(HaveOccurred())
+				doc.Address.City = "changed"
+				Expect(doc.Save()).To(Succeed())
+				Expect(doc.Reload()).To(Succeed())
+				Expect(doc.Address).To(Equal(UpdateBsonTestAddress{City: "changed", Zip: "elsewhere"}))
+			})
+		})
+		It("pushes elements appended to arrays", func() {
+			doc := UpdateBsonTestDocuments.New().(*UpdateBsonTestDocument)
+			doc.Tags = []string{"a", "b", "c", "d", "e"}
+			Expect(doc.ToUpdateBson()).To(Equal(bson.M{"$push": bson.M{"tags": bson.M{"$each": bson.A{"d", "e"}}}}))
+		})
+		It("pulls elements removed from arrays", func() {
+			doc := UpdateBsonTestDocuments.New().(*UpdateBsonTestDocument)
+			doc.Tags = []string{"a", "c"}
+			Expect(doc.ToUpdateBson()).To(Equal(bson.M{"$pull": bson.M{"tags": "b"}}))
+			doc.Tags = []string{"b"}
+			Expect(doc.ToUpdateBson()).To(Equal(bson.M{"$pullAll": bson.M{"tags": bson.A{"a", "c"}}}))
+		})
+		It("sets the whole array when the change is not expressible by a single operator", func() {
+			doc := UpdateBsonTestDocuments.New().(*UpdateBsonTestDocument)
+			doc.Tags = []string{"c", "b", "a"}
+			Expect(doc.ToUpdateBson()).To(Equal(bson.M{"$set": bson.M{"tags": bson.A{"c", "b", "a"}}}))
+			doc.Tags = []string{"a", "d"}
+			Expect(doc.ToUpdateBson()).To(Equal(bson.M{"$set": bson.M{"tags": bson.A{"a", "d"}}}))
+		})
+		It("keeps elements pushed concurrently when saved", func() {
+			OnlineDatabaseOnly(func() {
+				doc := UpdateBsonTestDocuments.New().(*UpdateBsonTestDocument)
+				Expect(doc.Save()).To(Succeed())
+				_, err := UpdateBsonTestDocuments.Where(mongoid.Q{"_id": doc.ID}).UpdateAll(mongoid.Update{}.Push("tags", "x"))
+				Expect(err).ToNot(HaveOccurred())
+				doc.Tags = []string{"a", "b", "c", "d"}
+				Expect(doc.Save()).To(Succeed())
+				Expect(doc.Reload()).To(Succeed())
+				Expect(doc.Tags).To(Equal([]string{"a", "b", "c", "x", "d"}))
+			})
+		})
+		It("removes the field from the stored record when saved", func() {
+			OnlineDatabaseOnly(func() {
+				doc := UpdateBsonTestDocuments.New().(*UpdateBsonTestDocument)
+				doc.Name = gofakeit.UUID()
+				Expect(doc.Save()).To(Succeed())
+				doc.Nickname = ""
+				Expect(doc.Save()).To(Succeed())
+				Expect(doc.IsChanged()).To(BeFalse())
+				Expect(UpdateBsonTestDocuments.Where(mongoid.Q{"_id": doc.ID, "nickname.$exists": false}).Exists()).To(BeTrue())
+				Expect(UpdateBsonTestDocuments.Where(mongoid.Q{"_id": doc.ID, "note": nil, "note.$exists": true}).Exists()).To(BeTrue())
+			})
+		})
+	})
+})
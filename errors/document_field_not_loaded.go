@@ -0,0 +1,36 @@
+package errors
+
+// DocumentFieldNotLoaded can occur when accessing a document field that was excluded from the query projection when the document was loaded (see Criteria.Only() and Criteria.Without())
+type DocumentFieldNotLoaded struct {
+	FieldName string
+}
+
+var _ error = new(DocumentFieldNotLoaded)
+var _ error = DocumentFieldNotLoaded{}
+var _ MongoidError = new(DocumentFieldNotLoaded)
+var _ MongoidError = DocumentFieldNotLoaded{}
+
+//IsDocumentFieldNotLoaded returns true if the given err is a DocumentFieldNotLoaded
+func IsDocumentFieldNotLoaded(err error) bool {
+	if _, ok := err.(DocumentFieldNotLoaded); ok {
+		return true
+	}
+	if _, ok := err.(*DocumentFieldNotLoaded); ok {
+		return true
+	}
+	return false
+}
+
+// Error implements error interface
+func (err DocumentFieldNotLoaded) Error() string {
+	if err.FieldName != "" {
+		return "DocumentFieldNotLoaded: " + err.FieldName
+	}
+	return "DocumentFieldNotLoaded"
+}
+
+// mongoidError implements MongoidError interface
+func (err DocumentFieldNotLoaded) mongoidError() {}
+
+// Unwrap implements MongoidError interface
+func (err DocumentFieldNotLoaded) Unwrap() error { return nil }
@@ -0,0 +1,16 @@
+package errors
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DocumentFieldNotLoaded", func() {
+	It("behaves", func() {
+		Expect(IsMongoidError(DocumentFieldNotLoaded{})).To(BeTrue())
+		Expect(IsMongoidError(&DocumentFieldNotLoaded{})).To(BeTrue())
+		Expect(IsDocumentFieldNotLoaded(DocumentFieldNotLoaded{})).To(BeTrue())
+		Expect(IsDocumentFieldNotLoaded(&DocumentFieldNotLoaded{})).To(BeTrue())
+		Expect(IsDocumentFieldNotLoaded(DocumentFieldNotFound{})).To(BeFalse())
+	})
+})
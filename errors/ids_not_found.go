@@ -0,0 +1,36 @@
+package errors
+
+import "fmt"
+
+// IDsNotFound can occur when specific ids were requested (see Criteria.Find), but some of them were not found.
+// It wraps ErrResultNotFound, so IsResultNotFound also holds for it.
+type IDsNotFound struct {
+	MissingIDs []interface{}
+}
+
+var _ error = new(IDsNotFound)
+var _ error = IDsNotFound{}
+var _ MongoidError = new(IDsNotFound)
+var _ MongoidError = IDsNotFound{}
+
+//IsIDsNotFound returns true if the given err is a IDsNotFound
+func IsIDsNotFound(err error) bool {
+	if _, ok := err.(IDsNotFound); ok {
+		return true
+	}
+	if _, ok := err.(*IDsNotFound); ok {
+		return true
+	}
+	return false
+}
+
+// Error implements error interface
+func (err IDsNotFound) Error() string {
+	return fmt.Sprintf("ResultNotFound - missing ids: %v", err.MissingIDs)
+}
+
+// mongoidError implements MongoidError interface
+func (err IDsNotFound) mongoidError() {}
+
+// Unwrap implements MongoidError interface
+func (err IDsNotFound) Unwrap() error { return ErrResultNotFound }
@@ -0,0 +1,24 @@
+package errors
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("IDsNotFound", func() {
+	It("behaves", func() {
+		Expect(IsMongoidError(IDsNotFound{})).To(BeTrue())
+		Expect(IsMongoidError(&IDsNotFound{})).To(BeTrue())
+		Expect(IsIDsNotFound(IDsNotFound{})).To(BeTrue())
+		Expect(IsIDsNotFound(&IDsNotFound{})).To(BeTrue())
+		Expect(IsIDsNotFound(ErrResultNotFound)).To(BeFalse())
+		Expect(IDsNotFound{MissingIDs: []interface{}{1, 2}}.Error()).To(Equal("ResultNotFound - missing ids: [1 2]"))
+	})
+	It("wraps ErrResultNotFound", func() {
+		var err error = IDsNotFound{MissingIDs: []interface{}{1, 2}}
+		Expect(IsResultNotFound(err)).To(BeTrue())
+		Expect(errors.Is(err, ErrResultNotFound)).To(BeTrue())
+	})
+})
@@ -0,0 +1,42 @@
+package errors
+
+// InvalidQueryOperator can occur when a query is given an operator that is not recognized, such as an unsupported "field.$op" suffix on a query key
+type InvalidQueryOperator struct {
+	FieldName string
+	Operator  string
+}
+
+var _ error = new(InvalidQueryOperator)
+var _ error = InvalidQueryOperator{}
+var _ MongoidError = new(InvalidQueryOperator)
+var _ MongoidError = InvalidQueryOperator{}
+
+//IsInvalidQueryOperator returns true if the given err is a InvalidQueryOperator
+func IsInvalidQueryOperator(err error) bool {
+	if _, ok := err.(InvalidQueryOperator); ok {
+		return true
+	}
+	if _, ok := err.(*InvalidQueryOperator); ok {
+		return true
+	}
+	return false
+}
+
+// Error implements error interface
+func (err InvalidQueryOperator) Error() string {
+	// example: "InvalidQueryOperator [field_name] - $op"
+	msg := "InvalidQueryOperator"
+	if err.FieldName != "" {
+		msg = msg + " [" + err.FieldName + "]"
+	}
+	if err.Operator != "" {
+		msg = msg + " - " + err.Operator
+	}
+	return msg
+}
+
+// mongoidError implements MongoidError interface
+func (err InvalidQueryOperator) mongoidError() {}
+
+// Unwrap implements MongoidError interface
+func (err InvalidQueryOperator) Unwrap() error { return nil }
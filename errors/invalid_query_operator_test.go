@@ -0,0 +1,16 @@
+package errors
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("InvalidQueryOperator", func() {
+	It("behaves", func() {
+		Expect(IsMongoidError(InvalidQueryOperator{})).To(BeTrue())
+		Expect(IsMongoidError(&InvalidQueryOperator{})).To(BeTrue())
+		Expect(IsInvalidQueryOperator(InvalidQueryOperator{})).To(BeTrue())
+		Expect(IsInvalidQueryOperator(&InvalidQueryOperator{})).To(BeTrue())
+		Expect(InvalidQueryOperator{FieldName: "field", Operator: "$nope"}.Error()).To(Equal("InvalidQueryOperator [field] - $nope"))
+	})
+})
@@ -11,8 +11,11 @@ var _ MongoidError = ResultNotFound{}
 // ErrResultNotFound is the value used when ResultNotFound is given
 var ErrResultNotFound error = ResultNotFound{}
 
-//IsResultNotFound returns true if the given err is ErrResultNotFound
+//IsResultNotFound returns true if the given err is ErrResultNotFound, or an IDsNotFound (which wraps it)
 func IsResultNotFound(err error) bool {
+	if IsIDsNotFound(err) {
+		return true
+	}
 	if err, ok := err.(ResultNotFound); ok {
 		return err == ErrResultNotFound
 	}
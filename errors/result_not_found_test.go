@@ -1,6 +1,8 @@
 package errors
 
 import (
+	"errors"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -12,5 +14,12 @@ var _ = Describe("ResultNotFound", func() {
 		Expect(IsResultNotFound(ResultNotFound{})).To(BeTrue())
 		Expect(IsResultNotFound(&ResultNotFound{})).To(BeTrue())
 		Expect(IsResultNotFound(ErrResultNotFound)).To(BeTrue())
+		Expect(IsResultNotFound(DocumentFieldNotFound{})).To(BeFalse())
+		Expect(ResultNotFound{}.Error()).To(Equal("ResultNotFound"))
+	})
+	It("is comparable to ErrResultNotFound", func() {
+		var err error = ResultNotFound{}
+		Expect(err == ErrResultNotFound).To(BeTrue())
+		Expect(errors.Is(err, ErrResultNotFound)).To(BeTrue())
 	})
 })
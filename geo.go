@@ -0,0 +1,130 @@
+package mongoid
+
+import (
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// GeoPoint is a GeoJSON Point, for document fields holding a location.
+// It is stored as {type: "Point", coordinates: [longitude, latitude]}, which can be indexed with CreateGeoIndex and queried with Near and Within.
+// A zero GeoPoint is stored as null, as an unset location; use a *GeoPoint field to store the point (0,0).
+type GeoPoint struct {
+	Longitude float64
+	Latitude  float64
+}
+
+// GeoPolygon is a GeoJSON Polygon, for document fields holding an area, or for use as the shape of a Within query.
+// The first ring is the exterior of the polygon, and any further rings are holes within it.
+// Each ring must be closed, meaning that the first and last points are the same.
+// A GeoPolygon without rings is stored as null.
+type GeoPolygon struct {
+	Rings [][]GeoPoint
+}
+
+// GeoGeometry is any of the GeoJSON types, which may be stored within documents and used as the geometry of geospatial queries
+type GeoGeometry interface {
+	toGeoJSON() bson.M
+}
+
+var _ GeoGeometry = GeoPoint{}
+var _ GeoGeometry = GeoPolygon{}
+
+// the GeoJSON types that can be stored within document fields, which are read back via fromGeoJSON (implemented with pointer receivers)
+type geoJSONField interface {
+	GeoGeometry
+	fromGeoJSON(geoJSON bson.M) bool
+}
+
+var _ geoJSONField = new(GeoPoint)
+var _ geoJSONField = new(GeoPolygon)
+
+var reflectTypeGeoJSONField = reflect.TypeOf((*geoJSONField)(nil)).Elem()
+
+// toGeoJSON implements GeoGeometry interface
+func (point GeoPoint) toGeoJSON() bson.M {
+	return bson.M{"type": "Point", "coordinates": point.coordinates()}
+}
+
+// the GeoJSON position of the point, longitude first
+func (point GeoPoint) coordinates() bson.A {
+	return bson.A{point.Longitude, point.Latitude}
+}
+
+// reads the point from a GeoJSON Point, returning false if the geoJSON is not a Point
+func (point *GeoPoint) fromGeoJSON(geoJSON bson.M) bool {
+	if geoJSON["type"] != "Point" {
+		return false
+	}
+	position, ok := geoPositionFromBson(geoJSON["coordinates"])
+	if ok {
+		*point = position
+	}
+	return ok
+}
+
+// toGeoJSON implements GeoGeometry interface
+func (polygon GeoPolygon) toGeoJSON() bson.M {
+	rings := bson.A{}
+	for _, ring := range polygon.Rings {
+		positions := bson.A{}
+		for _, point := range ring {
+			positions = append(positions, point.coordinates())
+		}
+		rings = append(rings, positions)
+	}
+	return bson.M{"type": "Polygon", "coordinates": rings}
+}
+
+// reads the polygon from a GeoJSON Polygon, returning false if the geoJSON is not a Polygon
+func (polygon *GeoPolygon) fromGeoJSON(geoJSON bson.M) bool {
+	if geoJSON["type"] != "Polygon" {
+		return false
+	}
+	rings, ok := geoJSON["coordinates"].(bson.A)
+	if !ok {
+		return false
+	}
+	newPolygon := GeoPolygon{Rings: make([][]GeoPoint, 0, len(rings))}
+	for _, ring := range rings {
+		positions, ok := ring.(bson.A)
+		if !ok {
+			return false
+		}
+		newRing := make([]GeoPoint, 0, len(positions))
+		for _, position := range positions {
+			point, ok := geoPositionFromBson(position)
+			if !ok {
+				return false
+			}
+			newRing = append(newRing, point)
+		}
+		newPolygon.Rings = append(newPolygon.Rings, newRing)
+	}
+	*polygon = newPolygon
+	return true
+}
+
+// reads a GeoJSON position ([longitude, latitude]) as a GeoPoint
+func geoPositionFromBson(position interface{}) (GeoPoint, bool) {
+	positionA, ok := position.(bson.A)
+	if !ok || len(positionA) < 2 {
+		return GeoPoint{}, false
+	}
+	longitude, lngOk := geoCoordinateFromBson(positionA[0])
+	latitude, latOk := geoCoordinateFromBson(positionA[1])
+	return GeoPoint{Longitude: longitude, Latitude: latitude}, lngOk && latOk
+}
+
+// reads a single coordinate, which may have been stored as any numeric type
+func geoCoordinateFromBson(coordinate interface{}) (float64, bool) {
+	switch v := coordinate.(type) {
+	case float64:
+		return v, true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	}
+	return 0, false
+}
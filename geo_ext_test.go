@@ -0,0 +1,50 @@
+package mongoid_test
+
+import (
+	"mongoid"
+
+	gofakeit "github.com/brianvoe/gofakeit/v6"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type GeoTestStore struct {
+	mongoid.Base
+	ID       mongoid.ObjectID `bson:"_id"`
+	Chain    string
+	Location mongoid.GeoPoint
+}
+
+var GeoTestStores = mongoid.Register(&GeoTestStore{})
+
+var _ = Describe("Criteria", func() {
+	Context("geospatial queries", func() {
+		It("find the nearest stores and the stores within an area", func() {
+			OnlineDatabaseOnly(func() {
+				_, err := GeoTestStores.CreateGeoIndex("Location")
+				Expect(err).ToNot(HaveOccurred())
+				chain := gofakeit.UUID()
+				newStore := func(longitude, latitude float64) *GeoTestStore {
+					store := GeoTestStores.New().(*GeoTestStore)
+					store.Chain = chain
+					store.Location = mongoid.GeoPoint{Longitude: longitude, Latitude: latitude}
+					Expect(store.Save()).To(Succeed())
+					return store
+				}
+				near := newStore(-73.97, 40.77)
+				farther := newStore(-73.90, 40.70)
+				newStore(-118.24, 34.05)
+
+				res := GeoTestStores.Where(mongoid.Q{"chain": chain}).Near("Location", mongoid.GeoPoint{Longitude: -73.98, Latitude: 40.78}, 50000).X()
+				Expect(res.Count()).To(Equal(uint(2)))
+				Expect(res.At(0).(*GeoTestStore).ID).To(Equal(near.ID))
+				Expect(res.At(0).(*GeoTestStore).Location).To(Equal(near.Location))
+				Expect(res.At(1).(*GeoTestStore).ID).To(Equal(farther.ID))
+
+				box := mongoid.GeoBox{BottomLeft: mongoid.GeoPoint{Longitude: -74, Latitude: 40.75}, TopRight: mongoid.GeoPoint{Longitude: -73.9, Latitude: 40.8}}
+				res = GeoTestStores.Where(mongoid.Q{"chain": chain}).Within("Location", box).X()
+				Expect(res.One().(*GeoTestStore).ID).To(Equal(near.ID))
+			})
+		})
+	})
+})
@@ -0,0 +1,116 @@
+package mongoid
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Geo types", func() {
+	type geoTestDoc struct {
+		Location GeoPoint
+		Optional *GeoPoint
+		Omitted  GeoPoint `bson:",omitempty"`
+		Area     *GeoPolygon
+	}
+	square := GeoPolygon{Rings: [][]GeoPoint{{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}}}}
+
+	It("are stored as GeoJSON", func() {
+		doc := geoTestDoc{Location: GeoPoint{Longitude: -73.97, Latitude: 40.77}, Area: &square}
+		Expect(structToBsonM(&doc)).To(Equal(bson.M{
+			"location": bson.M{"type": "Point", "coordinates": bson.A{-73.97, 40.77}},
+			"optional": nil,
+			"area": bson.M{"type": "Polygon", "coordinates": bson.A{
+				bson.A{bson.A{0.0, 0.0}, bson.A{1.0, 0.0}, bson.A{1.0, 1.0}, bson.A{0.0, 1.0}, bson.A{0.0, 0.0}},
+			}},
+		}))
+	})
+
+	It("are read from GeoJSON", func() {
+		doc := geoTestDoc{}
+		structValuesFromBsonM(&doc, bson.M{
+			"location": bson.M{"type": "Point", "coordinates": bson.A{-73.97, int32(40)}},
+			"optional": bson.M{"type": "Point", "coordinates": bson.A{1.5, 2.5}},
+			"area": bson.M{"type": "Polygon", "coordinates": bson.A{
+				bson.A{bson.A{0.0, 0.0}, bson.A{1.0, 0.0}, bson.A{1.0, 1.0}, bson.A{0.0, 1.0}, bson.A{0.0, 0.0}},
+			}},
+		})
+		Expect(doc.Location).To(Equal(GeoPoint{Longitude: -73.97, Latitude: 40}))
+		Expect(doc.Optional).To(Equal(&GeoPoint{Longitude: 1.5, Latitude: 2.5}))
+		Expect(doc.Area).To(Equal(&square))
+	})
+
+	It("round trip through bson", func() {
+		doc := geoTestDoc{Location: GeoPoint{Longitude: 10, Latitude: 20}, Omitted: GeoPoint{Longitude: 1, Latitude: 2}, Area: &square}
+		roundTrip := geoTestDoc{}
+		structValuesFromBsonM(&roundTrip, structToBsonM(&doc))
+		Expect(roundTrip).To(Equal(doc))
+	})
+
+	It("store zero shapes as null, or omit them when omitempty", func() {
+		type zeroGeoTestDoc struct {
+			Location GeoPoint
+			Explicit *GeoPoint
+			Omitted  GeoPoint `bson:",omitempty"`
+			Area     GeoPolygon
+			Empty    *GeoPolygon `bson:",omitempty"`
+		}
+		doc := zeroGeoTestDoc{Explicit: &GeoPoint{}, Empty: &GeoPolygon{}}
+		Expect(structToBsonM(&doc)).To(Equal(bson.M{
+			"location": nil,
+			"explicit": bson.M{"type": "Point", "coordinates": bson.A{0.0, 0.0}},
+			"area":     nil,
+		}))
+		roundTrip := zeroGeoTestDoc{Location: GeoPoint{Longitude: 1, Latitude: 2}}
+		structValuesFromBsonM(&roundTrip, structToBsonM(&doc))
+		Expect(roundTrip).To(Equal(zeroGeoTestDoc{Explicit: &GeoPoint{}}))
+	})
+
+	It("convert the elements of slices to and from GeoJSON", func() {
+		type sliceGeoTestDoc struct {
+			Visited []GeoPoint
+			Areas   []*GeoPolygon
+		}
+		doc := sliceGeoTestDoc{Visited: []GeoPoint{{Longitude: 1, Latitude: 2}, {}}, Areas: []*GeoPolygon{&square, nil}}
+		bsonM := structToBsonM(&doc)
+		Expect(bsonM["visited"]).To(Equal(bson.A{bson.M{"type": "Point", "coordinates": bson.A{1.0, 2.0}}, nil}))
+		Expect(bsonM["areas"]).To(Equal(bson.A{square.toGeoJSON(), nil}))
+		roundTrip := sliceGeoTestDoc{}
+		structValuesFromBsonM(&roundTrip, bsonM)
+		Expect(roundTrip).To(Equal(doc))
+	})
+})
+
+var _ = Describe("Criteria Geospatial", func() {
+	point := GeoPoint{Longitude: -73.97, Latitude: 40.77}
+	pointGeoJSON := bson.M{"type": "Point", "coordinates": bson.A{-73.97, 40.77}}
+
+	It("builds $near and $nearSphere filters", func() {
+		Expect(criteriaWhere(nil, nil).Near("location", point, 500).(*criteriaStruct).getFilterBsonD()).To(Equal(bson.D{
+			{Key: "location", Value: bson.M{"$near": bson.M{"$geometry": pointGeoJSON, "$maxDistance": 500.0}}},
+		}))
+		Expect(criteriaWhere(nil, nil).NearSphere("location", point, 0).(*criteriaStruct).getFilterBsonD()).To(Equal(bson.D{
+			{Key: "location", Value: bson.M{"$nearSphere": bson.M{"$geometry": pointGeoJSON}}},
+		}))
+	})
+
+	It("builds $geoWithin filters for each shape", func() {
+		square := GeoPolygon{Rings: [][]GeoPoint{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}}}
+		Expect(criteriaWhere(nil, nil).Within("location", square).(*criteriaStruct).getFilterBsonD()).To(Equal(bson.D{
+			{Key: "location", Value: bson.M{"$geoWithin": bson.M{"$geometry": square.toGeoJSON()}}},
+		}))
+		Expect(criteriaWhere(nil, nil).Within("location", GeoCenterSphere{Center: point, RadiusRadians: 0.1}).(*criteriaStruct).getFilterBsonD()).To(Equal(bson.D{
+			{Key: "location", Value: bson.M{"$geoWithin": bson.M{"$centerSphere": bson.A{bson.A{-73.97, 40.77}, 0.1}}}},
+		}))
+		Expect(criteriaWhere(nil, nil).Within("location", GeoBox{BottomLeft: GeoPoint{0, 0}, TopRight: GeoPoint{1, 1}}).(*criteriaStruct).getFilterBsonD()).To(Equal(bson.D{
+			{Key: "location", Value: bson.M{"$geoWithin": bson.M{"$box": bson.A{bson.A{0.0, 0.0}, bson.A{1.0, 1.0}}}}},
+		}))
+	})
+
+	It("builds $geoIntersects filters", func() {
+		Expect(criteriaWhere(nil, nil).Intersects("area", point).(*criteriaStruct).getFilterBsonD()).To(Equal(bson.D{
+			{Key: "area", Value: bson.M{"$geoIntersects": bson.M{"$geometry": pointGeoJSON}}},
+		}))
+	})
+})
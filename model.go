@@ -7,6 +7,8 @@ import (
 	"strings"
 
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
 )
 
 // ModelType represents a mongoid model/document type and provides methods to interact with the collection
@@ -17,7 +19,11 @@ type ModelType struct {
 	collectionName string
 	databaseName   string
 	clientName     string
-	defaultValue   BsonDocument // bson representation of default values to be applied during creation of brand new document/model instances
+	defaultValue   BsonDocument             // bson representation of default values to be applied during creation of brand new document/model instances
+	scopes         map[string]ScopeFunc     // named scopes, see Scope()
+	defaultScope   ScopeFunc                // scope applied to every new criteria chain, see SetDefaultScope()
+	readPreference *readpref.ReadPref       // default read preference for queries, see SetReadPreference()
+	readConcern    *readconcern.ReadConcern // default read concern for queries, see SetReadConcern()
 }
 
 var _ fmt.Stringer = ModelType{} // assert implements Stringer interface
@@ -142,12 +148,6 @@ func (model *ModelType) GetDefaultBSON() BsonDocument {
 	return BsonDocumentDeepCopy(model.defaultValue)
 }
 
-// NYI - ref: https://github.com/eshork/go-mongoid/issues/17
-// func (model *ModelType) SetDefaultScope() {
-// 	// log.Panic("NYI")
-// 	log.Error("NYI - ModelType.SetDefaultScope")
-// }
-
 // NYI - ref: https://github.com/eshork/go-mongoid/issues/18
 // func (model *ModelType) AddIndex() {
 // 	// log.Panic("NYI")
@@ -197,7 +197,7 @@ func (model *ModelType) getMongoCollectionHandle() *mongo.Collection {
 	client := model.GetClient()
 	dbName := model.GetDatabaseName()
 	collectionName := model.GetCollectionName()
-	collectionRef := client.getMongoCollectionHandle(dbName, collectionName)
+	collectionRef := client.getMongoCollectionHandle(dbName, collectionName, model.getCollectionOptions())
 	return collectionRef
 }
 
@@ -0,0 +1,129 @@
+package mongoid
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+)
+
+// GetBsonFieldPath converts the given field path into the field path used within the database.
+// Each dot separated segment of fieldPath may be given as either the Go struct field name or the bson field name,
+// and struct field names are converted the same way document fields are named when stored (bson struct tags, otherwise snake_case).
+// Segments that do not match a known struct field (such as map keys or operators) are passed through unaltered.
+func (model *ModelType) GetBsonFieldPath(fieldPath string) string {
+	bsonPath, _, _ := model.getBsonFieldPathType(fieldPath)
+	return bsonPath
+}
+
+// like GetBsonFieldPath, but also provides the Go type of the struct field at the end of the path and whether the full path was matched to struct fields
+func (model *ModelType) getBsonFieldPathType(fieldPath string) (bsonPath string, fieldType reflect.Type, found bool) {
+	if model == nil || model.rootTypeRef == nil {
+		return fieldPath, nil, false
+	}
+	return getBsonFieldPathByStructType(reflect.TypeOf(model.rootTypeRef), fieldPath)
+}
+
+// walks the given structType following fieldPath, returning the bson variant of the field path along with the type of the final field
+func getBsonFieldPathByStructType(structType reflect.Type, fieldPath string) (bsonPath string, fieldType reflect.Type, found bool) {
+	segments := strings.Split(fieldPath, ".")
+	curType := structType
+	found = true
+	for i, segment := range segments {
+		// follow pointers and slices into the element type, so paths can reach into embedded documents and arrays of embedded documents
+		for curType != nil && (curType.Kind() == reflect.Ptr || curType.Kind() == reflect.Slice || curType.Kind() == reflect.Array) {
+			curType = curType.Elem()
+		}
+		if curType == nil || curType.Kind() != reflect.Struct {
+			// no struct to search within, so whatever remains is passed through as-is
+			curType = nil
+			found = false
+			continue
+		}
+		bsonName, structField, ok := getStructFieldByName(curType, segment)
+		if !ok {
+			curType = nil
+			found = false
+			continue
+		}
+		segments[i] = bsonName
+		curType = structField.Type
+	}
+	return strings.Join(segments, "."), curType, found
+}
+
+// finds the struct field within structType that matches the given name (either the Go field name or the bson field name)
+// inlined structs are searched as though their fields were part of structType, same as structToBsonM
+func getStructFieldByName(structType reflect.Type, name string) (bsonName string, structField reflect.StructField, found bool) {
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if field.PkgPath != "" { // skip non-exported fields
+			continue
+		}
+		tagFieldName, _, _, tagInline := getBsonStructTagOpts(field)
+		if tagInline {
+			inlineType := field.Type
+			if inlineType.Kind() == reflect.Ptr {
+				inlineType = inlineType.Elem()
+			}
+			if inlineType.Kind() == reflect.Struct {
+				if bsonName, structField, found = getStructFieldByName(inlineType, name); found {
+					return
+				}
+			}
+			continue
+		}
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			continue // anonymous non-inline structs are omitted from bson
+		}
+
+		// process field name
+		thisFieldName := field.Name
+		switch tagFieldName {
+		case "": // empty tagFieldName means no explicit field name substitution was given, so snake_case the existing struct field name
+			thisFieldName = strcase.ToSnake(thisFieldName)
+		case "-": // "-" indicates this field is explicitly omitted, so we can skip this field
+			continue
+		default: // otherwise use tagFieldName as field name
+			thisFieldName = tagFieldName
+		}
+		if name == thisFieldName || name == field.Name {
+			return thisFieldName, field, true
+		}
+	}
+	return name, structField, false
+}
+
+// returns true if the given bson field path matches the fields of the model's struct, as far as it can be checked.
+// Only bson field names are matched (not Go struct field names), since the path is used as given within queries.
+// Array indexes are allowed after slice and array fields, and anything is allowed within fields that are not structs (ie, maps and interfaces).
+func (model *ModelType) hasFieldPath(fieldPath string) bool {
+	if model == nil || model.rootTypeRef == nil {
+		return true // nothing to check against
+	}
+	curType := reflect.TypeOf(model.rootTypeRef)
+	for _, segment := range strings.Split(fieldPath, ".") {
+		for curType.Kind() == reflect.Ptr {
+			curType = curType.Elem()
+		}
+		if curType.Kind() == reflect.Slice || curType.Kind() == reflect.Array {
+			curType = curType.Elem()
+			if _, err := strconv.Atoi(segment); err == nil {
+				continue // an array index
+			}
+			for curType.Kind() == reflect.Ptr {
+				curType = curType.Elem()
+			}
+		}
+		if curType.Kind() != reflect.Struct || reflect.PtrTo(curType).Implements(reflectTypeGeoJSONField) {
+			return true // GeoJSON fields are stored in their GeoJSON form, rather than as a struct of their fields
+		}
+		bsonName, structField, found := getStructFieldByName(curType, segment)
+		if !found || bsonName != segment {
+			return false
+		}
+		curType = structField.Type
+	}
+	return true
+}
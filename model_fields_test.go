@@ -0,0 +1,103 @@
+package mongoid
+
+import (
+	"reflect"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("getBsonFieldPathByStructType", func() {
+	type fieldsTestAddress struct {
+		City     string
+		PostCode string `bson:"zip"`
+	}
+	type fieldsTestInlined struct {
+		InlinedField int
+	}
+	type fieldsTestDoc struct {
+		Base
+		ID        ObjectID `bson:"_id"`
+		FirstName string
+		Renamed   string `bson:"other_name"`
+		Omitted   string `bson:"-"`
+		Address   fieldsTestAddress
+		AddressP  *fieldsTestAddress
+		Addresses []fieldsTestAddress
+		Inlined   fieldsTestInlined `bson:",inline"`
+		Extras    map[string]string
+	}
+	docType := reflect.TypeOf(&fieldsTestDoc{})
+
+	test := func(fieldPath, expectedPath string, expectedFound bool) {
+		bsonPath, _, found := getBsonFieldPathByStructType(docType, fieldPath)
+		ExpectWithOffset(1, bsonPath).To(Equal(expectedPath))
+		ExpectWithOffset(1, found).To(Equal(expectedFound))
+	}
+
+	It("converts struct field names to snake_case", func() {
+		test("FirstName", "first_name", true)
+	})
+	It("accepts bson field names", func() {
+		test("first_name", "first_name", true)
+		test("_id", "_id", true)
+	})
+	It("follows bson tag names", func() {
+		test("Renamed", "other_name", true)
+		test("other_name", "other_name", true)
+	})
+	It("does not match omitted fields", func() {
+		test("Omitted", "Omitted", false)
+	})
+	It("follows embedded structs, struct pointers and slices", func() {
+		test("Address.City", "address.city", true)
+		test("Address.PostCode", "address.zip", true)
+		test("AddressP.City", "address_p.city", true)
+		test("Addresses.City", "addresses.city", true)
+	})
+	It("finds inlined fields at the top level", func() {
+		test("InlinedField", "inlined_field", true)
+	})
+	It("passes through map keys and unknown fields", func() {
+		test("Extras.SomeKey", "extras.SomeKey", false)
+		test("NotAField", "NotAField", false)
+	})
+	It("reports the struct field type", func() {
+		_, fieldType, _ := getBsonFieldPathByStructType(docType, "Address.City")
+		Expect(fieldType).To(Equal(reflect.TypeOf("")))
+	})
+})
+
+var _ = Describe("ModelType.hasFieldPath", func() {
+	type fieldsTestAddress struct {
+		City string
+	}
+	type fieldsTestDoc struct {
+		Base
+		ID        ObjectID `bson:"_id"`
+		FirstName string
+		Addresses []fieldsTestAddress
+		Location  GeoPoint
+		Extras    map[string]string
+	}
+	model := &ModelType{modelName: "fieldsTestDoc", rootTypeRef: &fieldsTestDoc{}}
+
+	It("matches fields of the model", func() {
+		Expect(model.hasFieldPath("_id")).To(BeTrue())
+		Expect(model.hasFieldPath("first_name")).To(BeTrue())
+		Expect(model.hasFieldPath("FirstName")).To(BeFalse()) // queries use the path as given, so only bson names match
+	})
+	It("follows slices, with or without array indexes", func() {
+		Expect(model.hasFieldPath("addresses.city")).To(BeTrue())
+		Expect(model.hasFieldPath("addresses.0.city")).To(BeTrue())
+		Expect(model.hasFieldPath("addresses.0.zip")).To(BeFalse())
+	})
+	It("allows anything within maps and GeoJSON fields", func() {
+		Expect(model.hasFieldPath("extras.any_key")).To(BeTrue())
+		Expect(model.hasFieldPath("location.coordinates")).To(BeTrue())
+	})
+	It("does not match unknown fields", func() {
+		Expect(model.hasFieldPath("not_a_field")).To(BeFalse())
+		Expect(model.hasFieldPath("first_name.deeper")).To(BeTrue()) // strings are not structs, so cannot be checked
+	})
+})
@@ -73,6 +73,11 @@ func (model *ModelType) find(ctx context.Context, ids ...ObjectID) *Result {
 				}}}
 	}
 
+	if model.defaultScope != nil {
+		// only the filter of the default scope applies to finding by id
+		q = mergeBsonDFilters(newDefaultScopedCriteria(model).getFilterBsonD(), q)
+	}
+
 	collection := model.getMongoCollectionHandle()
 	cur, err := collection.Find(ctx, q)
 	if err != nil {
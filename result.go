@@ -26,13 +26,16 @@ type Result struct {
 	// ToAry() []IDocumentBase
 	// ToBsonAry() []bson.M
 
-	context     context.Context // context to pass to any future driver calls
-	model       *ModelType      // the ModelType associated with the query
-	streaming   bool            // track streaming access
-	lookback    []bson.M        // cache of records to support random access via At(), First(), Last(), etc
-	cursor      *mongo.Cursor   // the mongo driver cursor for the query
-	cursorIndex uint            // the current index of the driver cursor, what the next read will yield
-	closed      bool            // track cursor closed state
+	context     context.Context  // context to pass to any future driver calls
+	model       *ModelType       // the ModelType associated with the query
+	streaming   bool             // track streaming access
+	lookback    []bson.M         // cache of records to support random access via At(), First(), Last(), etc
+	cursor      *mongo.Cursor    // the mongo driver cursor for the query
+	cursorIndex uint             // the current index of the driver cursor, what the next read will yield
+	closed      bool             // track cursor closed state
+	loaded      *loadedFields    // the fields loaded by the query projection (nil when all fields were loaded)
+	persisted   bool             // true when the records are stored documents of the collection, so documents made from them are already persisted
+	textScores  map[uint]float64 // text search scores by record index, kept apart from the records since they are not document fields (see TextSearch)
 }
 
 func makeResult(ctx context.Context, cursor *mongo.Cursor, model *ModelType) *Result {
@@ -49,6 +52,7 @@ func makeResult(ctx context.Context, cursor *mongo.Cursor, model *ModelType) *Re
 		cursorIndex: 0,
 		streaming:   false,
 		closed:      false,
+		persisted:   true,
 	}
 }
 
@@ -126,10 +130,34 @@ func (res *Result) At(index uint) IDocumentBase {
 // retrieves the record at the given index, reading additional records from the db driver as needed
 func (res *Result) at(index uint) IDocumentBase {
 	result := res.atBson(index)
+	return res.makeDocument(result, index)
+}
+
+// creates a new document object from the record of the Result at the given index
+func (res *Result) makeDocument(result bson.M, index uint) IDocumentBase {
 	retAsIDocumentBase := makeDocument(res.model, result)
+	retAsIDocumentBase.setPersisted(res.persisted)
+	retAsIDocumentBase.setLoadedFields(res.loaded)
+	if textScore, found := res.textScores[index]; found {
+		retAsIDocumentBase.setTextScore(textScore)
+	}
 	return retAsIDocumentBase
 }
 
+// decodes the current record of the driver cursor into v, setting aside its text search score (if any) by the record index
+func (res *Result) decodeCursor(v *bson.M) {
+	if err := res.cursor.Decode(v); err != nil {
+		log.Panic(err)
+	}
+	if textScore, found := (*v)[textScoreKey]; found {
+		delete(*v, textScoreKey)
+		if res.textScores == nil {
+			res.textScores = make(map[uint]float64)
+		}
+		res.textScores[res.cursorIndex], _ = textScore.(float64)
+	}
+}
+
 // retrieves the record bson at the given index, reading additional records from the db driver as needed
 func (res *Result) atBson(index uint) bson.M {
 	// read responses until we have the record we need in lookback cache
@@ -188,6 +216,14 @@ func (res *Result) One() IDocumentBase {
 	return res.at(0)
 }
 
+// drops every record of the lookback cache after the first n; only for fully read results (see Count), so nothing further is read from the cursor
+func (res *Result) truncate(n uint) {
+	if uint(len(res.lookback)) > n {
+		res.lookback = res.lookback[:n]
+		res.cursorIndex = n
+	}
+}
+
 // read the next result from the query cursor, and append it to the lookback cache
 func (res *Result) readNextToLookback() bool {
 	log.Trace("Result.readNextToLookback()")
@@ -201,10 +237,7 @@ func (res *Result) readNextToLookback() bool {
 	}
 	if more { // process a new record if we found one
 		var result bson.M
-		err := res.cursor.Decode(&result)
-		if err != nil {
-			log.Panic(err)
-		}
+		res.decodeCursor(&result)
 		res.lookback = append(res.lookback, result)
 		res.cursorIndex++
 	} else { // close db cursor when we know there is no more data
@@ -234,10 +267,7 @@ func (res *Result) readNext(v *bson.M) bool {
 		log.Panic(err)
 	}
 	if more { // process a new record if we found one
-		err := res.cursor.Decode(v)
-		if err != nil {
-			log.Panic(err)
-		}
+		res.decodeCursor(v)
 		res.cursorIndex++
 	} else { // close db cursor when we know there is no more data
 		res.close()
@@ -262,20 +292,27 @@ func (res *Result) readNext(v *bson.M) bool {
 //    })
 //
 func (res *Result) ForEach(fn func(IDocumentBase) error) error {
-	// the heavy lifting is within ForEachBson
-	return res.ForEachBson(func(v bson.M) error {
-		asIDocumentBase := makeDocument(res.model, v)
+	// the heavy lifting is within forEachBson
+	return res.forEachBson(func(v bson.M, index uint) error {
+		asIDocumentBase := res.makeDocument(v, index)
 		return fn(asIDocumentBase)
 	})
 }
 
 // ForEachBson is similar to ForEach, but provides the raw bson.M instead of an IDocumentBase object
 func (res *Result) ForEachBson(fn func(bson.M) error) error {
+	return res.forEachBson(func(v bson.M, _ uint) error {
+		return fn(v)
+	})
+}
+
+// like ForEachBson, but also provides the index of each record
+func (res *Result) forEachBson(fn func(bson.M, uint) error) error {
 	if !res.streaming { // non-streaming implementation (records are stored to lookback cache as they are read)
 		count := res.Count() // this will read all records and close the mongo driver cursor for us
 		for i := uint(0); i < count; i++ {
 			result := res.atBson(i)
-			r := fn(result) // run the given fn
+			r := fn(result, i) // run the given fn
 			// if fn had a non-nil return, then we should stop and bubble that value upward
 			if r != nil {
 				return r
@@ -290,7 +327,9 @@ func (res *Result) ForEachBson(fn func(bson.M) error) error {
 		var result bson.M
 		more = res.readNext(&result)
 		if more {
-			r := fn(result) // run the given fn
+			index := res.cursorIndex - 1
+			r := fn(result, index)        // run the given fn
+			delete(res.textScores, index) // streamed records are not revisited, so their scores are no longer needed
 			// if fn had a non-nil return, then we should stop and bubble that value upward
 			if r != nil {
 				return r
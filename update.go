@@ -0,0 +1,216 @@
+package mongoid
+
+import (
+	"fmt"
+	mongoidError "mongoid/errors"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Updater provides the update document for UpdateAll. It is either a Query of update operators
+// (ie, Q{"$set": Q{"name": "bob"}, "$inc": Q{"visits": 1}}) or an Update built from its methods.
+// Field names within the operators may be given as either the Go struct field name or the bson field name.
+// Values are stored the same way as saving a document would store them for the named field (ie, GeoPoint values as GeoJSON).
+type Updater interface {
+	toUpdateBsonD(model *ModelType) (bson.D, error)
+}
+
+var _ Updater = Query{}
+var _ Updater = Update{}
+
+// Update builds an update document from atomic update operators, one operation at a time (ie, Update{}.Set("Name", "bob").Inc("Visits", 1)).
+// The zero value is an empty Update. Each method returns a new Update with the additional operation, leaving the original unaltered.
+type Update struct {
+	operators bson.D // each operator with the bson.D of its fields, in the order first given
+}
+
+// Set assigns the value to the field
+func (update Update) Set(field string, value interface{}) Update {
+	return update.withOperation("$set", field, value)
+}
+
+// Unset removes the fields from the document
+func (update Update) Unset(fields ...string) Update {
+	for _, field := range fields {
+		update = update.withOperation("$unset", field, "")
+	}
+	return update
+}
+
+// Inc increments the numeric field by the given amount, which may be negative
+func (update Update) Inc(field string, amount interface{}) Update {
+	return update.withOperation("$inc", field, amount)
+}
+
+// Mul multiplies the numeric field by the given factor
+func (update Update) Mul(field string, factor interface{}) Update {
+	return update.withOperation("$mul", field, factor)
+}
+
+// Min assigns the value to the field only when it is less than the current value
+func (update Update) Min(field string, value interface{}) Update {
+	return update.withOperation("$min", field, value)
+}
+
+// Max assigns the value to the field only when it is greater than the current value
+func (update Update) Max(field string, value interface{}) Update {
+	return update.withOperation("$max", field, value)
+}
+
+// Rename changes the name of the field; newName is given the same way as field
+func (update Update) Rename(field, newName string) Update {
+	return update.withOperation("$rename", field, newName)
+}
+
+// CurrentDate assigns the current date and time of the database server to the field
+func (update Update) CurrentDate(field string) Update {
+	return update.withOperation("$currentDate", field, true)
+}
+
+// Push appends the values to the array field
+func (update Update) Push(field string, values ...interface{}) Update {
+	return update.withOperation("$push", field, bson.D{{Key: "$each", Value: bson.A(values)}})
+}
+
+// AddToSet appends the values to the array field, skipping any that are already present in the array
+func (update Update) AddToSet(field string, values ...interface{}) Update {
+	return update.withOperation("$addToSet", field, bson.D{{Key: "$each", Value: bson.A(values)}})
+}
+
+// Pull removes every element of the array field that equals the given value, or matches the given condition (ie, Q{"$gte": 6})
+func (update Update) Pull(field string, valueOrCondition interface{}) Update {
+	if query, ok := valueOrCondition.(Query); ok {
+		valueOrCondition = queryToSortedBsonD(query)
+	}
+	return update.withOperation("$pull", field, valueOrCondition)
+}
+
+// PullAll removes every element of the array field that equals any of the values
+func (update Update) PullAll(field string, values ...interface{}) Update {
+	return update.withOperation("$pullAll", field, bson.A(values))
+}
+
+// returns a copy of the update with the field added to the given operator, replacing any earlier value for the same field
+func (update Update) withOperation(operator, field string, value interface{}) Update {
+	newOperators := make(bson.D, 0, len(update.operators)+1)
+	found := false
+	for _, element := range update.operators {
+		if element.Key == operator {
+			fieldsD := make(bson.D, 0, len(element.Value.(bson.D))+1)
+			for _, fieldE := range element.Value.(bson.D) {
+				if fieldE.Key != field {
+					fieldsD = append(fieldsD, fieldE)
+				}
+			}
+			element = bson.E{Key: operator, Value: append(fieldsD, bson.E{Key: field, Value: value})}
+			found = true
+		}
+		newOperators = append(newOperators, element)
+	}
+	if !found {
+		newOperators = append(newOperators, bson.E{Key: operator, Value: bson.D{{Key: field, Value: value}}})
+	}
+	return Update{operators: newOperators}
+}
+
+// toUpdateBsonD implements Updater interface
+func (update Update) toUpdateBsonD(model *ModelType) (bson.D, error) {
+	updateD := make(bson.D, 0, len(update.operators))
+	for _, element := range update.operators {
+		fieldsD, err := updateFieldsToBsonD(model, element.Key, element.Value.(bson.D))
+		if err != nil {
+			return nil, err
+		}
+		updateD = append(updateD, bson.E{Key: element.Key, Value: fieldsD})
+	}
+	return updateD, nil
+}
+
+// toUpdateBsonD implements Updater interface
+func (query Query) toUpdateBsonD(model *ModelType) (bson.D, error) {
+	updateD := bson.D{}
+	for _, operator := range sortedQueryKeys(query) {
+		value := query[operator]
+		var fieldsD bson.D
+		switch v := value.(type) {
+		case Query:
+			fieldsD = queryToSortedBsonD(v)
+		case bson.M:
+			fieldsD = queryToSortedBsonD(Query(v))
+		case map[string]interface{}:
+			fieldsD = queryToSortedBsonD(Query(v))
+		case bson.D:
+			fieldsD = v
+		default:
+			updateD = append(updateD, bson.E{Key: operator, Value: value}) // not a document of fields, so leave it for the server to reject
+			continue
+		}
+		convertedD, err := updateFieldsToBsonD(model, operator, fieldsD)
+		if err != nil {
+			return nil, err
+		}
+		updateD = append(updateD, bson.E{Key: operator, Value: convertedD})
+	}
+	return updateD, nil
+}
+
+// converts the field names of an update operator into bson field paths (including the new names of $rename),
+// and the values into the form stored for each field (see marshalValueForFieldType).
+// Returns an InvalidOperation error for $inc and $mul on fields that are not stored as numbers.
+func updateFieldsToBsonD(model *ModelType, operator string, fieldsD bson.D) (bson.D, error) {
+	bsonD := make(bson.D, 0, len(fieldsD))
+	for _, element := range fieldsD {
+		bsonPath, fieldType, _ := model.getBsonFieldPathType(element.Key)
+		value := element.Value
+		switch operator {
+		case "$rename":
+			if newName, ok := value.(string); ok {
+				value = model.GetBsonFieldPath(newName)
+			}
+		case "$set", "$setOnInsert", "$min", "$max":
+			value = marshalValueForFieldType(fieldType, value)
+		case "$inc", "$mul":
+			if fieldType != nil && !isStoredAsNumber(fieldType) {
+				return nil, mongoidError.InvalidOperation{
+					MethodName: "Criteria.UpdateAll",
+					Reason:     fmt.Sprintf("%v cannot be applied to '%v', since it is not stored as a number", operator, element.Key),
+				}
+			}
+		case "$push", "$addToSet", "$pull", "$pullAll":
+			value = updateArrayValueToBson(fieldType, value)
+		}
+		bsonD = append(bsonD, bson.E{Key: bsonPath, Value: value})
+	}
+	return bsonD, nil
+}
+
+// converts the elements given to an array update operator into the form stored for the elements of the array field:
+// the elements of {$each: [...]} ($push, $addToSet) and [...] ($pullAll), or a single element ($pull, unless it is a condition)
+func updateArrayValueToBson(fieldType reflect.Type, value interface{}) interface{} {
+	if fieldType == nil || (fieldType.Kind() != reflect.Slice && fieldType.Kind() != reflect.Array) {
+		return value
+	}
+	elemType := fieldType.Elem()
+	switch v := value.(type) {
+	case bson.D:
+		if len(v) == 1 && v[0].Key == "$each" {
+			if eachA, ok := v[0].Value.(bson.A); ok {
+				return bson.D{{Key: "$each", Value: marshalValuesForFieldType(elemType, eachA)}}
+			}
+		}
+		return value // a condition, such as {$in: [...]}
+	case bson.A:
+		return marshalValuesForFieldType(elemType, v)
+	}
+	return marshalValueForFieldType(elemType, value)
+}
+
+// converts each of the given values into the form stored for a field of fieldType
+func marshalValuesForFieldType(fieldType reflect.Type, values bson.A) bson.A {
+	converted := make(bson.A, 0, len(values))
+	for _, value := range values {
+		converted = append(converted, marshalValueForFieldType(fieldType, value))
+	}
+	return converted
+}
@@ -0,0 +1,93 @@
+package mongoid
+
+import (
+	mongoidError "mongoid/errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Update", func() {
+	type updateTestDoc struct {
+		Base
+		Name    string
+		Visits  int
+		Tags    []string
+		Renamed string `bson:"other_name"`
+		Count   uint64
+		Spot    GeoPoint
+		Visited []GeoPoint
+	}
+	model := &ModelType{modelName: "updateTestDoc", rootTypeRef: &updateTestDoc{}}
+
+	It("builds operators in the order given, with bson field names", func() {
+		update := Update{}.Set("Name", "bob").Inc("Visits", 1).Set("Renamed", "x").Unset("Tags")
+		Expect(update.toUpdateBsonD(model)).To(Equal(bson.D{
+			{Key: "$set", Value: bson.D{{Key: "name", Value: "bob"}, {Key: "other_name", Value: "x"}}},
+			{Key: "$inc", Value: bson.D{{Key: "visits", Value: 1}}},
+			{Key: "$unset", Value: bson.D{{Key: "tags", Value: ""}}},
+		}))
+	})
+
+	It("replaces earlier values for the same field", func() {
+		update := Update{}.Set("Name", "bob").Set("Name", "alice")
+		Expect(update.toUpdateBsonD(model)).To(Equal(bson.D{
+			{Key: "$set", Value: bson.D{{Key: "name", Value: "alice"}}},
+		}))
+	})
+
+	It("leaves the original unaltered", func() {
+		original := Update{}.Set("Name", "bob")
+		original.Inc("Visits", 1)
+		Expect(original.toUpdateBsonD(model)).To(HaveLen(1))
+		Expect(Update{}.toUpdateBsonD(model)).To(BeEmpty())
+	})
+
+	It("builds array and set operators", func() {
+		update := Update{}.Push("Tags", "a", "b").AddToSet("Tags", "c").Pull("Tags", Q{"$in": bson.A{"d"}}).PullAll("Tags", "e")
+		Expect(update.toUpdateBsonD(model)).To(Equal(bson.D{
+			{Key: "$push", Value: bson.D{{Key: "tags", Value: bson.D{{Key: "$each", Value: bson.A{"a", "b"}}}}}},
+			{Key: "$addToSet", Value: bson.D{{Key: "tags", Value: bson.D{{Key: "$each", Value: bson.A{"c"}}}}}},
+			{Key: "$pull", Value: bson.D{{Key: "tags", Value: bson.D{{Key: "$in", Value: bson.A{"d"}}}}}},
+			{Key: "$pullAll", Value: bson.D{{Key: "tags", Value: bson.A{"e"}}}},
+		}))
+	})
+
+	It("converts both names of Rename", func() {
+		Expect(Update{}.Rename("Name", "Renamed").toUpdateBsonD(model)).To(Equal(bson.D{
+			{Key: "$rename", Value: bson.D{{Key: "name", Value: "other_name"}}},
+		}))
+	})
+
+	It("accepts a Query of update operators", func() {
+		query := Q{"$set": Q{"Name": "bob", "Renamed": "x"}, "$inc": bson.M{"Visits": 2}}
+		Expect(query.toUpdateBsonD(model)).To(Equal(bson.D{
+			{Key: "$inc", Value: bson.D{{Key: "visits", Value: 2}}},
+			{Key: "$set", Value: bson.D{{Key: "name", Value: "bob"}, {Key: "other_name", Value: "x"}}},
+		}))
+	})
+
+	It("stores values the same way as saving a document", func() {
+		spot := GeoPoint{Longitude: 1.5, Latitude: 2.5}
+		update := Update{}.Set("Count", uint64(5)).Set("Visits", 3).Set("Spot", spot).Push("Visited", spot)
+		Expect(update.toUpdateBsonD(model)).To(Equal(bson.D{
+			{Key: "$set", Value: bson.D{
+				{Key: "count", Value: "5"},
+				{Key: "visits", Value: int32(3)},
+				{Key: "spot", Value: bson.M{"type": "Point", "coordinates": bson.A{1.5, 2.5}}},
+			}},
+			{Key: "$push", Value: bson.D{{Key: "visited", Value: bson.D{{Key: "$each", Value: bson.A{
+				bson.M{"type": "Point", "coordinates": bson.A{1.5, 2.5}},
+			}}}}}},
+		}))
+	})
+
+	It("rejects $inc and $mul on fields not stored as numbers", func() {
+		_, err := Update{}.Inc("Count", 1).toUpdateBsonD(model)
+		Expect(err).To(BeAssignableToTypeOf(mongoidError.InvalidOperation{}))
+		_, err = Q{"$mul": Q{"Count": 2}}.toUpdateBsonD(model)
+		Expect(err).To(BeAssignableToTypeOf(mongoidError.InvalidOperation{}))
+	})
+})
@@ -1,6 +1,7 @@
 package util
 
 import (
+	"fmt"
 	"reflect"
 	"strconv"
 
@@ -11,8 +12,18 @@ import (
 // If fromValue is already the type of intoType, it may be returned directly, but it is not guaranteed to do so.
 // If a value conversion would result in loss of data or precision, this function will panic.
 func MarshalFromDB(intoType reflect.Type, fromValue interface{}) interface{} {
+	value, err := TryMarshalFromDB(intoType, fromValue)
+	if err != nil {
+		log.Panicf("%v", err)
+	}
+	return value
+}
+
+// TryMarshalFromDB is the same as MarshalFromDB, but returns an error rather than panicking when the value cannot be converted
+// (ie, a float64 or negative value stored in a uint field by another application).
+func TryMarshalFromDB(intoType reflect.Type, fromValue interface{}) (interface{}, error) {
 	if reflect.TypeOf(fromValue) == intoType {
-		return fromValue
+		return fromValue, nil
 	}
 
 	switch intoType.Kind() {
@@ -28,11 +39,14 @@ func MarshalFromDB(intoType reflect.Type, fromValue interface{}) interface{} {
 		dstPtr := reflect.New(intoType)
 		dst := reflect.Indirect(dstPtr)
 		src := reflect.ValueOf(fromValue)
+		if src.Kind() < reflect.Int || src.Kind() > reflect.Int64 {
+			return nil, fmt.Errorf("Error detected while storing %v within %v: not an integer", reflect.TypeOf(fromValue), intoType)
+		}
 		if dst.OverflowInt(src.Int()) {
-			log.Panicf("Overflow detected while storing %v within %v", src.Type(), dst.Type())
+			return nil, fmt.Errorf("Overflow detected while storing %v within %v", src.Type(), dst.Type())
 		}
 		dst.SetInt(src.Int())
-		return dst.Interface()
+		return dst.Interface(), nil
 	case reflect.Uint8:
 		fallthrough
 	case reflect.Uint16:
@@ -55,13 +69,13 @@ func MarshalFromDB(intoType reflect.Type, fromValue interface{}) interface{} {
 		}
 		srcUint64, srcUint64Err := strconv.ParseUint(srcStr, 10, 64)
 		if srcUint64Err != nil {
-			log.Panicf("Error detected while storing %v within %v: %v", reflect.TypeOf(fromValue), intoType, srcUint64Err)
+			return nil, fmt.Errorf("Error detected while storing %v within %v: %v", reflect.TypeOf(fromValue), intoType, srcUint64Err)
 		}
 		if dst.OverflowUint(srcUint64) {
-			log.Panicf("Overflow detected while storing %v within %v", reflect.TypeOf(fromValue), intoType)
+			return nil, fmt.Errorf("Overflow detected while storing %v within %v", reflect.TypeOf(fromValue), intoType)
 		}
 		dst.SetUint(srcUint64)
-		return dst.Interface()
+		return dst.Interface(), nil
 	case reflect.Complex64:
 		fallthrough
 	case reflect.Complex128:
@@ -85,14 +99,13 @@ func MarshalFromDB(intoType reflect.Type, fromValue interface{}) interface{} {
 		}
 		srcComplex128, srcComplex128Err := strconv.ParseComplex(srcStr, dstBits)
 		if srcComplex128Err != nil {
-			log.Panicf("Error detected while storing %v within %v: %v", reflect.TypeOf(fromValue), intoType, srcComplex128Err)
+			return nil, fmt.Errorf("Error detected while storing %v within %v: %v", reflect.TypeOf(fromValue), intoType, srcComplex128Err)
 		}
 		if dst.OverflowComplex(srcComplex128) {
-			log.Panicf("Overflow detected while storing %v within %v", reflect.TypeOf(fromValue), intoType)
+			return nil, fmt.Errorf("Overflow detected while storing %v within %v", reflect.TypeOf(fromValue), intoType)
 		}
 		dst.SetComplex(srcComplex128)
-		return dst.Interface()
+		return dst.Interface(), nil
 	}
-	log.Panicf("Unhandled kind: %v", intoType.Kind())
-	return nil
+	return nil, fmt.Errorf("Unhandled kind: %v", intoType.Kind())
 }
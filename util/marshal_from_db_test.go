@@ -0,0 +1,38 @@
+package util
+
+import (
+	"mongoid/log"
+	"reflect"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TryMarshalFromDB()", func() {
+	It("converts stored values into the given type", func() {
+		Expect(TryMarshalFromDB(reflect.TypeOf(int(0)), int32(5))).To(Equal(int(5)))
+		Expect(TryMarshalFromDB(reflect.TypeOf(uint64(0)), "18446744073709551615")).To(Equal(uint64(18446744073709551615)))
+		Expect(TryMarshalFromDB(reflect.TypeOf(complex128(0)), "(1+2i)")).To(Equal(complex128(1 + 2i)))
+	})
+	It("returns an error for values that do not fit the given type", func() {
+		for intoType, fromValue := range map[reflect.Type]interface{}{
+			reflect.TypeOf(uint(0)):      float64(2.5),
+			reflect.TypeOf(uint32(0)):    int32(-1),
+			reflect.TypeOf(int8(0)):      int32(300),
+			reflect.TypeOf(int(0)):       "5",
+			reflect.TypeOf(complex64(0)): true,
+			reflect.TypeOf(float32(0)):   "unhandled",
+		} {
+			_, err := TryMarshalFromDB(intoType, fromValue)
+			Expect(err).To(HaveOccurred(), "%v from %#v", intoType, fromValue)
+		}
+	})
+})
+
+var _ = Describe("MarshalFromDB()", func() {
+	It("panics for values that do not fit the given type", func() {
+		Expect(func() {
+			log.WithMute(func() { MarshalFromDB(reflect.TypeOf(uint(0)), float64(2.5)) })
+		}).To(Panic())
+	})
+})